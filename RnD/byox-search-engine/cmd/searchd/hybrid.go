@@ -0,0 +1,38 @@
+package main
+
+// rrfK is the reciprocal rank fusion constant: a higher k flattens the
+// contribution of low ranks, so a document that's merely "pretty good" in
+// both lanes can still outscore one that's #1 in only one.
+const rrfK = 60
+
+// ReciprocalRankFusion merges the lexical and vector lanes' rank-ordered
+// hits into one list scored by sum(1 / (rrfK + rank)) across whichever
+// lane(s) a document appears in, then re-sorts by the fused score.
+func ReciprocalRankFusion(lexical, vector []ScoredDoc) []ScoredDoc {
+	fused := make(map[string]*ScoredDoc)
+
+	apply := func(lane []ScoredDoc) {
+		for _, hit := range lane {
+			entry, ok := fused[hit.Entry.ID]
+			if !ok {
+				seed := hit
+				seed.Score = 0
+				fused[hit.Entry.ID] = &seed
+				entry = fused[hit.Entry.ID]
+			}
+			entry.Score += 1.0 / float64(rrfK+hit.Rank)
+		}
+	}
+	apply(lexical)
+	apply(vector)
+
+	out := make([]ScoredDoc, 0, len(fused))
+	for _, hit := range fused {
+		out = append(out, *hit)
+	}
+	sortScoredDocs(out)
+	for i := range out {
+		out[i].Rank = i + 1
+	}
+	return out
+}
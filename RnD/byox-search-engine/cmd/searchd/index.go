@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// bm25K1 and bm25B are the standard Okapi BM25 tuning constants: k1 controls
+// term-frequency saturation, b controls document-length normalization.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+var tokenRe = regexp.MustCompile(`[a-z0-9]+`)
+
+// tokenize lowercases and splits text on runs of non-alphanumeric characters.
+// It's intentionally simple (no stemming, no stopword list) since the corpus
+// is small enough that BM25's own IDF weighting already suppresses common
+// words.
+func tokenize(text string) []string {
+	return tokenRe.FindAllString(strings.ToLower(text), -1)
+}
+
+// KnowledgeEntry is one line of a knowledge-outbox *.jsonl file.
+type KnowledgeEntry struct {
+	ID   string            `json:"id"`
+	Text string            `json:"text"`
+	Meta map[string]string `json:"meta,omitempty"`
+}
+
+// Index is an in-memory inverted index with BM25 scoring over the
+// knowledge-outbox corpus. It's safe for concurrent search and update.
+type Index struct {
+	mu sync.RWMutex
+
+	docs     map[string]KnowledgeEntry
+	docLen   map[string]int
+	postings map[string]map[string]int // term -> docID -> term frequency
+	totalLen int
+
+	// fileDocs tracks which doc IDs came from which *.jsonl file, so
+	// RemoveFile can prune exactly what a deleted file contributed.
+	fileDocs map[string][]string
+}
+
+// NewIndex returns an empty Index ready for AddDocument/Search.
+func NewIndex() *Index {
+	return &Index{
+		docs:     make(map[string]KnowledgeEntry),
+		docLen:   make(map[string]int),
+		postings: make(map[string]map[string]int),
+		fileDocs: make(map[string][]string),
+	}
+}
+
+// AddDocument tokenizes entry.Text and folds it into the index, replacing
+// any prior document with the same ID.
+func (idx *Index) AddDocument(entry KnowledgeEntry) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(entry.ID)
+
+	terms := tokenize(entry.Text)
+	idx.docs[entry.ID] = entry
+	idx.docLen[entry.ID] = len(terms)
+	idx.totalLen += len(terms)
+
+	counts := make(map[string]int, len(terms))
+	for _, t := range terms {
+		counts[t]++
+	}
+	for term, freq := range counts {
+		bucket, ok := idx.postings[term]
+		if !ok {
+			bucket = make(map[string]int)
+			idx.postings[term] = bucket
+		}
+		bucket[entry.ID] = freq
+	}
+}
+
+// removeLocked drops a previously indexed document so re-indexing (e.g. on
+// an fsnotify Write event) doesn't double-count its terms. Caller holds mu.
+func (idx *Index) removeLocked(id string) {
+	if _, ok := idx.docs[id]; !ok {
+		return
+	}
+	idx.totalLen -= idx.docLen[id]
+	delete(idx.docLen, id)
+	delete(idx.docs, id)
+	for term, bucket := range idx.postings {
+		if _, ok := bucket[id]; ok {
+			delete(bucket, id)
+			if len(bucket) == 0 {
+				delete(idx.postings, term)
+			}
+		}
+	}
+}
+
+// Remove drops id from the index, for files deleted out from under a watched
+// knowledge-outbox directory.
+func (idx *Index) Remove(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(id)
+}
+
+// RemoveFile drops every document that was loaded from path, returning their
+// IDs so callers (e.g. watch.go's fsnotify Remove handler) can also prune
+// them from the vector lane.
+func (idx *Index) RemoveFile(path string) []string {
+	idx.mu.Lock()
+	ids := idx.fileDocs[path]
+	delete(idx.fileDocs, path)
+	for _, id := range ids {
+		idx.removeLocked(id)
+	}
+	idx.mu.Unlock()
+	return ids
+}
+
+// N returns the number of indexed documents.
+func (idx *Index) N() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// avgDocLen returns the mean document length in terms, used by BM25's length
+// normalization. Caller holds at least a read lock.
+func (idx *Index) avgDocLenLocked() float64 {
+	if len(idx.docs) == 0 {
+		return 0
+	}
+	return float64(idx.totalLen) / float64(len(idx.docs))
+}
+
+// ScoredDoc is one search hit: the source entry plus its rank score (BM25,
+// cosine similarity, or RRF fusion score depending on which lane produced
+// it) and the 1-based rank within its own lane.
+type ScoredDoc struct {
+	Entry KnowledgeEntry
+	Score float64
+	Rank  int
+}
+
+// SearchLexical scores every document containing at least one query term via
+// BM25 and returns the top n hits ordered by descending score.
+func (idx *Index) SearchLexical(query string, n int) []ScoredDoc {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	terms := tokenize(query)
+	avgLen := idx.avgDocLenLocked()
+	scores := make(map[string]float64)
+
+	for _, term := range terms {
+		bucket, ok := idx.postings[term]
+		if !ok {
+			continue
+		}
+		df := len(bucket)
+		idf := math.Log((float64(len(idx.docs))-float64(df)+0.5)/(float64(df)+0.5) + 1)
+		for id, tf := range bucket {
+			dl := float64(idx.docLen[id])
+			denom := float64(tf) + bm25K1*(1-bm25B+bm25B*dl/avgLen)
+			scores[id] += idf * (float64(tf) * (bm25K1 + 1) / denom)
+		}
+	}
+
+	return topN(scores, idx.docs, n)
+}
+
+// topN turns a docID->score map into a rank-ordered, capped ScoredDoc slice.
+// Shared by the lexical and vector lanes so their output shapes line up for
+// hybrid.go's reciprocal rank fusion.
+func topN(scores map[string]float64, docs map[string]KnowledgeEntry, n int) []ScoredDoc {
+	out := make([]ScoredDoc, 0, len(scores))
+	for id, score := range scores {
+		out = append(out, ScoredDoc{Entry: docs[id], Score: score})
+	}
+	sortScoredDocs(out)
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	for i := range out {
+		out[i].Rank = i + 1
+	}
+	return out
+}
+
+func sortScoredDocs(docs []ScoredDoc) {
+	// Simple insertion sort: the knowledge-outbox corpus this serves is
+	// small (a few thousand entries at most), so an O(n^2) worst case
+	// isn't worth pulling in sort.Slice's reflection overhead for.
+	for i := 1; i < len(docs); i++ {
+		for j := i; j > 0 && docs[j].Score > docs[j-1].Score; j-- {
+			docs[j], docs[j-1] = docs[j-1], docs[j]
+		}
+	}
+}
+
+// LoadDir walks dir for *.jsonl files and adds every line (one KnowledgeEntry
+// per line) to idx. Malformed lines are skipped rather than aborting the
+// whole crawl, since a single bad entry shouldn't take down /index.
+func (idx *Index) LoadDir(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("index: read %s: %w", dir, err)
+	}
+
+	added := 0
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".jsonl") {
+			continue
+		}
+		n, err := idx.loadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return added, err
+		}
+		added += n
+	}
+	return added, nil
+}
+
+// loadFile indexes every KnowledgeEntry line in path, used both by LoadDir's
+// initial crawl and watch.go's incremental re-index on fsnotify events.
+func (idx *Index) loadFile(path string) (int, error) {
+	entries, err := readEntries(path)
+	if err != nil {
+		return 0, err
+	}
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		idx.AddDocument(entry)
+		ids = append(ids, entry.ID)
+	}
+	idx.mu.Lock()
+	idx.fileDocs[path] = ids
+	idx.mu.Unlock()
+	return len(entries), nil
+}
+
+// readEntries parses every KnowledgeEntry line in a *.jsonl file. It's
+// shared by loadFile (which folds entries into the lexical index) and
+// watch.go's embedding re-index (which needs the raw entries to re-embed,
+// not just their effect on the inverted index).
+func readEntries(path string) ([]KnowledgeEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("index: open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var entries []KnowledgeEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry KnowledgeEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			continue
+		}
+		if entry.ID == "" {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
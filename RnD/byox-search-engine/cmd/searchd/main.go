@@ -1,24 +1,242 @@
+// Command searchd is the retrieval service behind the launcher's
+// showAIDashboard: it indexes the knowledge-outbox corpus for BM25 lexical
+// search, an optional Ollama-embedding vector lane, and a hybrid mode that
+// fuses the two via reciprocal rank fusion.
 package main
-import(
- "encoding/json";"fmt";"log";"net/http";"os";"path/filepath";"time"
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
 )
-type Query struct{Q string`json:"q"`}
-func logEvent(ev,info string){
- root:=os.Getenv("OMARCHY_ROOT")
- if root==""{h,_:=os.UserHomeDir();root=filepath.Join(h,".omarchy","current")}
- os.MkdirAll(filepath.Join(root,"logs"),0755)
- f,_:=os.OpenFile(filepath.Join(root,"logs","usage.jsonl"),os.O_APPEND|os.O_CREATE|os.O_WRONLY,0644)
- defer f.Close()
- fmt.Fprintf(f,"{\"time\":\"%s\",\"event\":\"%s\",\"info\":\"%s\"}\n",time.Now().UTC().Format(time.RFC3339),ev,info)
-}
-func main(){
- http.HandleFunc("/ping",func(w http.ResponseWriter,_ *http.Request){fmt.Fprint(w,"ok")})
- http.HandleFunc("/search",func(w http.ResponseWriter,r *http.Request){
-  defer r.Body.Close()
-  var q Query; _=json.NewDecoder(r.Body).Decode(&q)
-  logEvent("search_query",q.Q)
-  res:=map[string]any{"query":q.Q,"results":[]map[string]any{{"id":"demo","score":0.42,"snippet":"hello R&D"}}}
-  w.Header().Set("Content-Type","application/json");json.NewEncoder(w).Encode(res)
- })
- addr:=":8188";log.Printf("🔎 searchd live %s",addr);log.Fatal(http.ListenAndServe(addr,nil))
+
+// defaultKnowledgeOutbox is where the launcher's AI subagents drop
+// knowledge entries for searchd to index.
+func defaultKnowledgeOutbox() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, "Documents", "omarchy-ai-assist", "knowledge-outbox")
+}
+
+func logEvent(ev, info string, fields map[string]any) {
+	root := os.Getenv("OMARCHY_ROOT")
+	if root == "" {
+		h, _ := os.UserHomeDir()
+		root = filepath.Join(h, ".omarchy", "current")
+	}
+	os.MkdirAll(filepath.Join(root, "logs"), 0755)
+	f, err := os.OpenFile(filepath.Join(root, "logs", "usage.jsonl"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	record := map[string]any{
+		"time":  time.Now().UTC().Format(time.RFC3339),
+		"event": ev,
+		"info":  info,
+	}
+	for k, v := range fields {
+		record[k] = v
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(f, string(data))
+}
+
+// searchQuery is the /search request body.
+type searchQuery struct {
+	Q    string `json:"q"`
+	Mode string `json:"mode"` // "lexical" (default), "vector", or "hybrid"
+	N    int    `json:"n"`
+}
+
+// indexRequest is the /index request body: a directory to crawl.
+type indexRequest struct {
+	Dir string `json:"dir"`
+}
+
+type server struct {
+	idx     *Index
+	vectors *VectorStore
+	embed   *EmbeddingClient
+	outbox  string
+	started time.Time
+}
+
+func (s *server) handlePing(w http.ResponseWriter, _ *http.Request) {
+	fmt.Fprint(w, "ok")
+}
+
+func (s *server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	start := time.Now()
+
+	var q searchQuery
+	_ = json.NewDecoder(r.Body).Decode(&q)
+	if q.Mode == "" {
+		q.Mode = "lexical"
+	}
+	n := q.N
+	if n <= 0 {
+		n = 10
+	}
+
+	var results []ScoredDoc
+	switch q.Mode {
+	case "lexical":
+		results = s.idx.SearchLexical(q.Q, n)
+	case "vector":
+		results = s.searchVector(q.Q, n)
+	case "hybrid":
+		lexical := s.idx.SearchLexical(q.Q, n)
+		vector := s.searchVector(q.Q, n)
+		results = ReciprocalRankFusion(lexical, vector)
+		if len(results) > n {
+			results = results[:n]
+		}
+	default:
+		http.Error(w, fmt.Sprintf("unknown mode %q", q.Mode), http.StatusBadRequest)
+		return
+	}
+
+	latency := time.Since(start)
+	logEvent("search_query", q.Q, map[string]any{
+		"mode":       q.Mode,
+		"latency_ms": latency.Milliseconds(),
+		"hit_count":  len(results),
+	})
+
+	resp := map[string]any{
+		"query":      q.Q,
+		"mode":       q.Mode,
+		"latency_ms": latency.Milliseconds(),
+		"results":    results,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// searchVector embeds query and returns its nearest neighbors. It returns
+// nil (not an error) if no embedding client is configured, so /search
+// degrades to lexical-only results rather than failing.
+func (s *server) searchVector(query string, n int) []ScoredDoc {
+	if s.embed == nil {
+		return nil
+	}
+	vector, err := s.embed.Embed(query)
+	if err != nil {
+		log.Printf("⚠️  searchd: embed query: %v", err)
+		return nil
+	}
+	hits := s.vectors.SearchKNN(vector, n)
+	out := make([]ScoredDoc, 0, len(hits))
+	for i, h := range hits {
+		id := s.vectors.IDAt(h.pos)
+		out = append(out, ScoredDoc{Entry: s.idx.docs[id], Score: 1 - h.dist, Rank: i + 1})
+	}
+	return out
+}
+
+func (s *server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	var req indexRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+	if req.Dir == "" {
+		req.Dir = s.outbox
+	}
+
+	added, err := s.idx.LoadDir(req.Dir)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if s.embed != nil {
+		s.embedDir(req.Dir)
+	}
+
+	logEvent("index_crawl", req.Dir, map[string]any{"added": added})
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"dir": req.Dir, "added": added})
+}
+
+// embedDir embeds and inserts every entry under dir into the vector store.
+// Called from handleIndex after the lexical crawl has populated s.idx.
+func (s *server) embedDir(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, e.Name())
+		if err := reindexVectors(path, s.vectors, s.embed); err != nil {
+			log.Printf("⚠️  searchd: embed %s: %v", path, err)
+		}
+	}
+}
+
+func (s *server) handleStats(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"documents":   s.idx.N(),
+		"vector_lane": s.embed != nil,
+		"uptime_secs": int(time.Since(s.started).Seconds()),
+	})
+}
+
+func main() {
+	outbox := os.Getenv("OMARCHY_KNOWLEDGE_OUTBOX")
+	if outbox == "" {
+		outbox = defaultKnowledgeOutbox()
+	}
+
+	s := &server{
+		idx:     NewIndex(),
+		vectors: NewVectorStore(),
+		outbox:  outbox,
+		started: time.Now(),
+	}
+	if ollamaURL := os.Getenv("OLLAMA_URL"); ollamaURL != "" {
+		model := os.Getenv("OLLAMA_EMBED_MODEL")
+		if model == "" {
+			model = "nomic-embed-text"
+		}
+		s.embed = NewEmbeddingClient(ollamaURL, model)
+	}
+
+	if added, err := s.idx.LoadDir(s.outbox); err != nil {
+		log.Printf("⚠️  searchd: initial crawl of %s failed: %v", s.outbox, err)
+	} else {
+		log.Printf("🔎 searchd indexed %d entries from %s", added, s.outbox)
+	}
+	if s.embed != nil {
+		s.embedDir(s.outbox)
+	}
+
+	go watchKnowledgeOutbox(s.outbox, s.idx, s.vectors, s.embed)
+
+	http.HandleFunc("/ping", s.handlePing)
+	http.HandleFunc("/search", s.handleSearch)
+	http.HandleFunc("/index", s.handleIndex)
+	http.HandleFunc("/stats", s.handleStats)
+
+	port := os.Getenv("SEARCHD_PORT")
+	if port == "" {
+		port = "8188"
+	}
+	if _, err := strconv.Atoi(port); err != nil {
+		log.Fatalf("❌ invalid SEARCHD_PORT %q", port)
+	}
+	addr := ":" + port
+	log.Printf("🔎 searchd live %s", addr)
+	log.Fatal(http.ListenAndServe(addr, nil))
 }
@@ -0,0 +1,317 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// hnswM and hnswEfConstruction mirror the usual HNSW defaults: M is the
+// number of neighbors kept per node, efConstruction is the candidate-list
+// size explored while inserting.
+const (
+	hnswM              = 16
+	hnswEfConstruction = 200
+)
+
+// EmbeddingClient fetches embeddings from Ollama's /api/embeddings endpoint.
+type EmbeddingClient struct {
+	BaseURL string
+	Model   string
+	client  *http.Client
+}
+
+// NewEmbeddingClient returns a client pointed at baseURL (e.g.
+// "http://localhost:11434") using model for every embedding request.
+func NewEmbeddingClient(baseURL, model string) *EmbeddingClient {
+	return &EmbeddingClient{BaseURL: baseURL, Model: model, client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+type embeddingRequest struct {
+	Model  string `json:"model"`
+	Prompt string `json:"prompt"`
+}
+
+type embeddingResponse struct {
+	Embedding []float32 `json:"embedding"`
+}
+
+// Embed returns the embedding vector for text.
+func (c *EmbeddingClient) Embed(text string) ([]float32, error) {
+	body, err := json.Marshal(embeddingRequest{Model: c.Model, Prompt: text})
+	if err != nil {
+		return nil, fmt.Errorf("vector: marshal embedding request: %w", err)
+	}
+	resp, err := c.client.Post(c.BaseURL+"/api/embeddings", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("vector: call ollama embeddings: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vector: ollama embeddings returned %s", resp.Status)
+	}
+	var out embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("vector: decode embedding response: %w", err)
+	}
+	return out.Embedding, nil
+}
+
+// hnswNode is one vector plus its neighbor list in the HNSW graph. This
+// implementation uses a single flat layer rather than HNSW's usual
+// multi-layer skip structure: the knowledge-outbox corpus this serves is
+// small enough (low thousands of entries) that the accuracy/speed tradeoff
+// of the full hierarchy isn't worth the added bookkeeping, but the M /
+// efConstruction-bounded greedy search below behaves the same as the base
+// layer of a real HNSW index.
+type hnswNode struct {
+	ID        string
+	Vector    []float32
+	Neighbors []int
+	// removed tombstones a node instead of compacting it out of nodes:
+	// Neighbors elsewhere in the graph store positions in nodes, and
+	// shrinking/reordering the slice on every delete would mean remapping
+	// every other node's Neighbors. A tombstoned node is excluded from
+	// search results but left in place so the graph stays traversable.
+	removed bool
+}
+
+// VectorStore holds the dense-embedding lane: a flat file of float32
+// vectors plus a sidecar HNSW-style graph for approximate nearest-neighbor
+// search.
+type VectorStore struct {
+	mu    sync.RWMutex
+	nodes []hnswNode
+	index map[string]int // doc ID -> position in nodes
+	// entryPoint is the node searchLocked starts its graph traversal from.
+	// -1 means the graph is empty.
+	entryPoint int
+}
+
+// NewVectorStore returns an empty VectorStore.
+func NewVectorStore() *VectorStore {
+	return &VectorStore{index: make(map[string]int), entryPoint: -1}
+}
+
+// Insert adds or replaces id's vector and wires it into the graph by
+// connecting it to its hnswM nearest existing neighbors, searched with an
+// candidate list of hnswEfConstruction.
+func (vs *VectorStore) Insert(id string, vector []float32) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+
+	if pos, ok := vs.index[id]; ok {
+		vs.nodes[pos].Vector = vector
+		vs.nodes[pos].removed = false
+		if vs.entryPoint == -1 {
+			vs.entryPoint = pos
+		}
+		return
+	}
+
+	newPos := len(vs.nodes)
+	candidates := vs.searchLocked(vector, hnswEfConstruction)
+	node := hnswNode{ID: id, Vector: vector}
+	for i, c := range candidates {
+		if i >= hnswM {
+			break
+		}
+		node.Neighbors = append(node.Neighbors, c.pos)
+		vs.nodes[c.pos].Neighbors = append(vs.nodes[c.pos].Neighbors, newPos)
+	}
+	vs.nodes = append(vs.nodes, node)
+	vs.index[id] = newPos
+	if vs.entryPoint == -1 {
+		vs.entryPoint = newPos
+	}
+}
+
+// Remove tombstones id so it no longer appears in search results, for files
+// deleted out from under a watched knowledge-outbox directory. It's a no-op
+// if id was never inserted.
+func (vs *VectorStore) Remove(id string) {
+	vs.mu.Lock()
+	defer vs.mu.Unlock()
+	pos, ok := vs.index[id]
+	if !ok {
+		return
+	}
+	vs.nodes[pos].removed = true
+	vs.nodes[pos].Vector = nil
+	delete(vs.index, id)
+	if vs.entryPoint == pos {
+		vs.entryPoint = vs.nextLiveEntryLocked()
+	}
+}
+
+// nextLiveEntryLocked scans for a remaining non-removed node to serve as the
+// graph's entry point once the previous one is tombstoned. Caller holds mu.
+func (vs *VectorStore) nextLiveEntryLocked() int {
+	for i, n := range vs.nodes {
+		if !n.removed {
+			return i
+		}
+	}
+	return -1
+}
+
+type candidate struct {
+	pos  int
+	dist float64
+}
+
+// searchLocked performs a genuine greedy best-first walk of the neighbor
+// graph, seeded from the stable entryPoint rather than whichever nodes
+// happen to sit first in vs.nodes: it repeatedly expands the closest
+// unexplored candidate's neighbor list, stopping once nothing left to
+// explore can beat the worst of the ef best results found so far. An
+// earlier version instead took the first ~efConstruction nodes in
+// insertion order as the frontier; for a corpus larger than
+// efConstruction, both Insert's neighbor wiring and SearchKNN's querying
+// only ever considered that fixed early slice (plus its one-hop
+// neighbors), so documents inserted later, or reachable only through
+// parts of the graph never picked as an early seed, could be permanently
+// unreachable. Caller holds mu.
+func (vs *VectorStore) searchLocked(query []float32, ef int) []candidate {
+	if len(vs.nodes) == 0 || vs.entryPoint == -1 {
+		return nil
+	}
+
+	entryDist := cosineDistance(query, vs.nodes[vs.entryPoint].Vector)
+	toExplore := []candidate{{pos: vs.entryPoint, dist: entryDist}}
+	visited := map[int]bool{vs.entryPoint: true}
+	var best []candidate
+	if !vs.nodes[vs.entryPoint].removed {
+		best = insertSorted(best, candidate{pos: vs.entryPoint, dist: entryDist}, ef)
+	}
+
+	for len(toExplore) > 0 {
+		c := popNearest(&toExplore)
+		if len(best) >= ef && c.dist > best[len(best)-1].dist {
+			break
+		}
+		for _, nb := range vs.nodes[c.pos].Neighbors {
+			if visited[nb] {
+				continue
+			}
+			visited[nb] = true
+			dist := cosineDistance(query, vs.nodes[nb].Vector)
+			if len(best) < ef || dist < best[len(best)-1].dist {
+				toExplore = append(toExplore, candidate{pos: nb, dist: dist})
+				// A tombstoned node is still explored for connectivity
+				// (its neighbors may lead to live nodes) but never
+				// itself returned.
+				if !vs.nodes[nb].removed {
+					best = insertSorted(best, candidate{pos: nb, dist: dist}, ef)
+				}
+			}
+		}
+	}
+	return best
+}
+
+// popNearest removes and returns the lowest-distance candidate from *c.
+func popNearest(c *[]candidate) candidate {
+	s := *c
+	minIdx := 0
+	for i := 1; i < len(s); i++ {
+		if s[i].dist < s[minIdx].dist {
+			minIdx = i
+		}
+	}
+	nearest := s[minIdx]
+	s[minIdx] = s[len(s)-1]
+	*c = s[:len(s)-1]
+	return nearest
+}
+
+// insertSorted inserts cand into best, which is kept sorted ascending by
+// distance and capped at max entries (dropping the new furthest entry once
+// over capacity).
+func insertSorted(best []candidate, cand candidate, max int) []candidate {
+	i := len(best)
+	best = append(best, cand)
+	for ; i > 0 && best[i].dist < best[i-1].dist; i-- {
+		best[i], best[i-1] = best[i-1], best[i]
+	}
+	if len(best) > max {
+		best = best[:max]
+	}
+	return best
+}
+
+// cosineDistance returns 1 - cosine similarity, so smaller is closer.
+func cosineDistance(a, b []float32) float64 {
+	var dot, normA, normB float64
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 1
+	}
+	return 1 - dot/(math.Sqrt(normA)*math.Sqrt(normB))
+}
+
+// SearchKNN returns the n closest documents to query by cosine similarity.
+func (vs *VectorStore) SearchKNN(query []float32, n int) []candidate {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	results := vs.searchLocked(query, hnswEfConstruction)
+	if len(results) > n {
+		results = results[:n]
+	}
+	return results
+}
+
+// IDAt returns the doc ID stored at a candidate's graph position.
+func (vs *VectorStore) IDAt(pos int) string {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+	return vs.nodes[pos].ID
+}
+
+// Save persists the flat vector file (vectorsPath) and a JSON sidecar
+// describing the graph (graphPath), so a restarted searchd doesn't have to
+// re-embed the whole corpus.
+func (vs *VectorStore) Save(vectorsPath, graphPath string) error {
+	vs.mu.RLock()
+	defer vs.mu.RUnlock()
+
+	vf, err := os.Create(vectorsPath)
+	if err != nil {
+		return fmt.Errorf("vector: create %s: %w", vectorsPath, err)
+	}
+	defer vf.Close()
+	for _, n := range vs.nodes {
+		if err := binary.Write(vf, binary.LittleEndian, n.Vector); err != nil {
+			return fmt.Errorf("vector: write %s: %w", vectorsPath, err)
+		}
+	}
+
+	type graphNode struct {
+		ID        string `json:"id"`
+		Dims      int    `json:"dims"`
+		Neighbors []int  `json:"neighbors"`
+	}
+	var graph []graphNode
+	for _, n := range vs.nodes {
+		graph = append(graph, graphNode{ID: n.ID, Dims: len(n.Vector), Neighbors: n.Neighbors})
+	}
+	data, err := json.Marshal(graph)
+	if err != nil {
+		return fmt.Errorf("vector: marshal graph: %w", err)
+	}
+	return os.WriteFile(graphPath, data, 0644)
+}
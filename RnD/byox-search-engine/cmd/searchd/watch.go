@@ -0,0 +1,89 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchKnowledgeOutbox watches dir for *.jsonl writes/creates/removes and
+// keeps idx (and, if embed is non-nil, the vector store) in sync without
+// requiring a manual /index call. It runs until the watcher errors out or
+// the process exits.
+func watchKnowledgeOutbox(dir string, idx *Index, vs *VectorStore, embed *EmbeddingClient) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("⚠️  searchd: fsnotify unavailable, incremental indexing disabled: %v", err)
+		return
+	}
+	if err := watcher.Add(dir); err != nil {
+		log.Printf("⚠️  searchd: watch %s: %v", dir, err)
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if !strings.EqualFold(extOf(event.Name), ".jsonl") {
+				continue
+			}
+			switch {
+			case event.Op&(fsnotify.Write|fsnotify.Create) != 0:
+				if _, err := idx.loadFile(event.Name); err != nil {
+					log.Printf("⚠️  searchd: reindex %s: %v", event.Name, err)
+					continue
+				}
+				if embed != nil {
+					if err := reindexVectors(event.Name, vs, embed); err != nil {
+						log.Printf("⚠️  searchd: re-embed %s: %v", event.Name, err)
+					}
+				}
+			case event.Op&fsnotify.Remove != 0:
+				ids := idx.RemoveFile(event.Name)
+				if embed != nil {
+					for _, id := range ids {
+						vs.Remove(id)
+					}
+				}
+				log.Printf("ℹ️  searchd: %s removed; pruned %d entries from the index", event.Name, len(ids))
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("⚠️  searchd: watcher error: %v", err)
+		}
+	}
+}
+
+// extOf returns the filename extension including the leading dot, with no
+// import of path/filepath just for this one call site.
+func extOf(name string) string {
+	i := strings.LastIndexByte(name, '.')
+	if i < 0 {
+		return ""
+	}
+	return name[i:]
+}
+
+// reindexVectors re-embeds every entry in path and inserts the resulting
+// vectors into vs, keeping the dense lane current alongside the lexical
+// index's own reload of the same file.
+func reindexVectors(path string, vs *VectorStore, embed *EmbeddingClient) error {
+	entries, err := readEntries(path)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		vector, err := embed.Embed(entry.Text)
+		if err != nil {
+			return err
+		}
+		vs.Insert(entry.ID, vector)
+	}
+	return nil
+}
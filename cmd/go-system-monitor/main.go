@@ -8,23 +8,26 @@ import (
 	"os"
 	"runtime"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // SystemMonitor represents the main monitoring system
 type SystemMonitor struct {
-	startTime   time.Time
-	aiEndpoint  string
-	nodejsPort  int
+	startTime  time.Time
+	aiEndpoint string
+	nodejsPort int
 }
 
 // SystemMetrics holds current system metrics
 type SystemMetrics struct {
-	Timestamp    time.Time `json:"timestamp"`
-	Uptime       string    `json:"uptime"`
-	MemoryUsage  MemInfo   `json:"memory_usage"`
-	CPUUsage     CPUInfo   `json:"cpu_usage"`
-	GoRoutines   int       `json:"go_routines"`
-	AIStatus     AIStatus  `json:"ai_status"`
+	Timestamp   time.Time `json:"timestamp"`
+	Uptime      string    `json:"uptime"`
+	MemoryUsage MemInfo   `json:"memory_usage"`
+	CPUUsage    CPUInfo   `json:"cpu_usage"`
+	GoRoutines  int       `json:"go_routines"`
+	AIStatus    AIStatus  `json:"ai_status"`
 }
 
 // MemInfo holds memory information
@@ -37,10 +40,10 @@ type MemInfo struct {
 
 // CPUInfo holds CPU information
 type CPUInfo struct {
-	NumCPU     int    `json:"num_cpu"`
-	GoVersion  string `json:"go_version"`
-	OS         string `json:"os"`
-	Arch       string `json:"arch"`
+	NumCPU    int    `json:"num_cpu"`
+	GoVersion string `json:"go_version"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
 }
 
 // AIStatus holds AI team status
@@ -55,9 +58,9 @@ type AIStatus struct {
 // NewSystemMonitor creates a new system monitor instance
 func NewSystemMonitor() *SystemMonitor {
 	return &SystemMonitor{
-		startTime:   time.Now(),
-		aiEndpoint:  "http://localhost:3000/api/status",
-		nodejsPort:  3001,  // Changed from 3000 to avoid conflicts
+		startTime:  time.Now(),
+		aiEndpoint: "http://localhost:3000/api/status",
+		nodejsPort: 3001, // Changed from 3000 to avoid conflicts
 	}
 }
 
@@ -70,8 +73,8 @@ func (sm *SystemMonitor) collectMetrics() SystemMetrics {
 	aiStatus := sm.getAIStatus()
 
 	return SystemMetrics{
-		Timestamp:   time.Now(),
-		Uptime:      time.Since(sm.startTime).String(),
+		Timestamp: time.Now(),
+		Uptime:    time.Since(sm.startTime).String(),
 		MemoryUsage: MemInfo{
 			Alloc:      m.Alloc,
 			TotalAlloc: m.TotalAlloc,
@@ -133,15 +136,65 @@ func (sm *SystemMonitor) getAIStatus() AIStatus {
 	return status
 }
 
+// promDescs are the Prometheus metric descriptors this monitor publishes at
+// /metrics, alongside the existing /api/metrics JSON used by the dashboard.
+var (
+	promUptimeSeconds = prometheus.NewDesc("omarchy_uptime_seconds", "Seconds since the system monitor started.", nil, nil)
+	promGoGoroutines  = prometheus.NewDesc("omarchy_go_goroutines", "Number of goroutines currently running.", nil, nil)
+	promMemoryAlloc   = prometheus.NewDesc("omarchy_memory_alloc_bytes", "Bytes of heap memory currently allocated.", nil, nil)
+	promAIActive      = prometheus.NewDesc("omarchy_ai_active_assistants", "Number of currently active AI assistants.", nil, nil)
+	promAIHealth      = prometheus.NewDesc("omarchy_ai_health", "AI team health: 0=offline, 1=degraded, 2=healthy.", nil, nil)
+)
+
+// promCollector adapts SystemMonitor to prometheus.Collector, calling
+// collectMetrics() fresh on every scrape rather than caching a snapshot, so
+// /metrics and /api/metrics never disagree.
+type promCollector struct{ sm *SystemMonitor }
+
+func (c promCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- promUptimeSeconds
+	ch <- promGoGoroutines
+	ch <- promMemoryAlloc
+	ch <- promAIActive
+	ch <- promAIHealth
+}
+
+func (c promCollector) Collect(ch chan<- prometheus.Metric) {
+	m := c.sm.collectMetrics()
+	ch <- prometheus.MustNewConstMetric(promUptimeSeconds, prometheus.GaugeValue, time.Since(c.sm.startTime).Seconds())
+	ch <- prometheus.MustNewConstMetric(promGoGoroutines, prometheus.GaugeValue, float64(m.GoRoutines))
+	ch <- prometheus.MustNewConstMetric(promMemoryAlloc, prometheus.GaugeValue, float64(m.MemoryUsage.Alloc))
+	ch <- prometheus.MustNewConstMetric(promAIActive, prometheus.GaugeValue, float64(m.AIStatus.ActiveAssistants))
+	ch <- prometheus.MustNewConstMetric(promAIHealth, prometheus.GaugeValue, aiHealthValue(m.AIStatus.Health))
+}
+
+// aiHealthValue maps AIStatus.Health to the 0/1/2 scale Prometheus
+// dashboards expect for an enum-like gauge.
+func aiHealthValue(health string) float64 {
+	switch health {
+	case "healthy":
+		return 2
+	case "degraded":
+		return 1
+	default:
+		return 0
+	}
+}
+
 // startWebServer starts the HTTP server for monitoring dashboard
 func (sm *SystemMonitor) startWebServer() {
+	prometheus.MustRegister(promCollector{sm: sm})
+
 	http.HandleFunc("/", sm.handleIndex)
 	http.HandleFunc("/api/metrics", sm.handleMetrics)
 	http.HandleFunc("/api/health", sm.handleHealth)
+	http.HandleFunc("/events", sm.handleEvents)
+	http.Handle("/metrics", promhttp.Handler())
 
 	log.Printf("🚀 Omarchy System Monitor starting on port %d", sm.nodejsPort)
 	log.Printf("📊 Dashboard: http://localhost:%d", sm.nodejsPort)
 	log.Printf("📡 Metrics API: http://localhost:%d/api/metrics", sm.nodejsPort)
+	log.Printf("📈 Prometheus: http://localhost:%d/metrics", sm.nodejsPort)
 
 	if err := http.ListenAndServe(fmt.Sprintf(":%d", sm.nodejsPort), nil); err != nil {
 		log.Fatal("❌ Failed to start web server:", err)
@@ -207,29 +260,38 @@ func (sm *SystemMonitor) handleIndex(w http.ResponseWriter, r *http.Request) {
             return parseFloat((bytes / Math.pow(k, i)).toFixed(2)) + ' ' + sizes[i];
         }
 
-        async function updateMetrics() {
-            try {
-                const response = await fetch('/api/metrics');
-                const data = await response.json();
+        function applyMetrics(data) {
+            document.getElementById('uptime').textContent = data.uptime;
+            document.getElementById('memory').textContent = formatBytes(data.memory_usage.alloc);
+            document.getElementById('goroutines').textContent = data.go_routines;
 
-                document.getElementById('uptime').textContent = data.uptime;
-                document.getElementById('memory').textContent = formatBytes(data.memory_usage.alloc);
-                document.getElementById('goroutines').textContent = data.go_routines;
+            const aiStatus = document.getElementById('ai-status');
+            const aiCard = document.getElementById('ai-status-card');
+            aiStatus.textContent = data.ai_status.active_assistants + "/" + data.ai_status.total_assistants + " Active";
 
-                const aiStatus = document.getElementById('ai-status');
-                const aiCard = document.getElementById('ai-status-card');
-                aiStatus.textContent = data.ai_status.active_assistants + "/" + data.ai_status.total_assistants + " Active";
+            // Update card color based on health
+            aiCard.className = 'metric-card status-' + data.ai_status.health;
+        }
 
-                // Update card color based on health
-                aiCard.className = 'metric-card status-' + data.ai_status.health;
+        async function updateMetrics() {
+            try {
+                const response = await fetch('/api/metrics');
+                applyMetrics(await response.json());
             } catch (error) {
                 console.error('Failed to fetch metrics:', error);
             }
         }
 
-        // Update metrics immediately and then every 5 seconds
-        updateMetrics();
-        setInterval(updateMetrics, 5000);
+        // Prefer a live SSE stream from /events; fall back to 5s polling if
+        // EventSource isn't available (or the connection can't be upgraded).
+        if (window.EventSource) {
+            const events = new EventSource('/events');
+            events.addEventListener('metrics', (e) => applyMetrics(JSON.parse(e.data)));
+            events.onerror = () => console.error('SSE connection lost, retrying...');
+        } else {
+            updateMetrics();
+            setInterval(updateMetrics, 5000);
+        }
     </script>
 </body>
 </html>`
@@ -237,6 +299,39 @@ func (sm *SystemMonitor) handleIndex(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprint(w, html)
 }
 
+// handleEvents streams SystemMetrics snapshots over Server-Sent Events so
+// the dashboard's AI-status card updates without waiting out the fixed 5s
+// polling interval /api/metrics used to require.
+func (sm *SystemMonitor) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		data, err := json.Marshal(sm.collectMetrics())
+		if err == nil {
+			fmt.Fprintf(w, "event: metrics\ndata: %s\n\n", data)
+			flusher.Flush()
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // handleMetrics serves the metrics as JSON
 func (sm *SystemMonitor) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -286,4 +381,4 @@ func main() {
 
 	// Start the web server
 	monitor.startWebServer()
-}
\ No newline at end of file
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zebadiee/omarchy-ai-assist/pkg/platformspec"
+)
+
+// Target classifies where a generated artifact conceptually belongs, so an
+// Emitter can decide whether it becomes a raw file write, a home-manager
+// `home.file` entry, an `xdg.configFile` entry, or a systemd user unit.
+type Target int
+
+const (
+	TargetHome Target = iota
+	TargetXDGConfig
+	TargetSystemdUser
+)
+
+// FileSpec describes one generated artifact independent of backend: Name
+// is the path relative to the target's root ($HOME, $XDG_CONFIG_HOME, or
+// ~/.config/systemd/user), mirroring how home-manager addresses files.
+type FileSpec struct {
+	Name     string
+	Target   Target
+	Contents string
+	Mode     os.FileMode
+}
+
+// Emitter turns a set of FileSpecs plus the OmServiceSpec into a concrete
+// deployment artifact: either real files on disk (FilesystemEmitter) or a
+// single declarative Nix module (NixModuleEmitter). Both backends consume
+// the same data so the generator's behavior doesn't fork per target.
+type Emitter interface {
+	EmitFiles(specs []FileSpec) error
+	EmitServiceSpec(path string, spec platformspec.OmServiceSpec) error
+}
+
+// FilesystemEmitter is the original behavior: write every FileSpec
+// straight to disk under the paths this package has always used.
+type FilesystemEmitter struct{}
+
+func (FilesystemEmitter) rootFor(target Target) string {
+	switch target {
+	case TargetXDGConfig:
+		return hyprConfigDir[:strings.LastIndex(hyprConfigDir, "/conf.d")] // ~/.config
+	case TargetSystemdUser:
+		return systemdDir
+	default:
+		return baseDir
+	}
+}
+
+func (e FilesystemEmitter) EmitFiles(specs []FileSpec) error {
+	for _, spec := range specs {
+		path := filepath.Join(e.rootFor(spec.Target), spec.Name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := writeFile(path, spec.Contents, spec.Mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (FilesystemEmitter) EmitServiceSpec(path string, spec platformspec.OmServiceSpec) error {
+	return writeJSONLD(path, spec)
+}
+
+// NixModuleEmitter renders the same FileSpecs and OmServiceSpec as a single
+// home-manager module exposing `programs.omarchyWagon.*` options, so the
+// tool is reproducible on any machine instead of hard-coding
+// /home/zebadiee paths.
+type NixModuleEmitter struct {
+	// OutPath is where the rendered module is written, e.g.
+	// "configs/nix/omarchy-wagon.nix".
+	OutPath string
+}
+
+func (n NixModuleEmitter) EmitFiles(specs []FileSpec) error {
+	sorted := make([]FileSpec, len(specs))
+	copy(sorted, specs)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var b strings.Builder
+	b.WriteString("{ config, lib, pkgs, ... }:\n\n")
+	b.WriteString("with lib;\n\n")
+	b.WriteString("let\n  cfg = config.programs.omarchyWagon;\nin\n{\n")
+	b.WriteString("  options.programs.omarchyWagon = {\n")
+	b.WriteString("    enable = mkEnableOption \"Omarchy Wagon Wheels\";\n\n")
+	b.WriteString("    openrouterKeyFile = mkOption {\n")
+	b.WriteString("      type = types.nullOr types.path;\n")
+	b.WriteString("      default = null;\n")
+	b.WriteString("      description = \"Path to a file containing OPENROUTER_API_KEY.\";\n")
+	b.WriteString("    };\n\n")
+	b.WriteString("    playbookExtras = mkOption {\n")
+	b.WriteString("      type = types.attrsOf types.lines;\n")
+	b.WriteString("      default = { };\n")
+	b.WriteString("      description = \"Extra playbook/<name>.md entries to merge in alongside the defaults.\";\n")
+	b.WriteString("    };\n")
+	b.WriteString("  };\n\n")
+	b.WriteString("  config = mkIf cfg.enable {\n")
+
+	homeFiles, xdgFiles, systemdServices := partitionByTarget(sorted)
+
+	writeFileBlock(&b, "home.file", homeFiles)
+	writeFileBlock(&b, "xdg.configFile", xdgFiles)
+
+	if len(systemdServices) > 0 {
+		b.WriteString("    systemd.user.services.onboarding = {\n")
+		b.WriteString("      Unit.Description = \"Omarchy First Login Onboarding\";\n")
+		b.WriteString("      Service = {\n")
+		b.WriteString("        Type = \"oneshot\";\n")
+		b.WriteString("        ExecStart = \"%h/.npm-global/onboarding.sh\";\n")
+		b.WriteString("      };\n")
+		b.WriteString("      Install.WantedBy = [ \"default.target\" ];\n")
+		b.WriteString("    };\n\n")
+	}
+
+	b.WriteString("    home.file.\".npm-global/wagon-wheels.conf\".source =\n")
+	b.WriteString("      mkIf (cfg.openrouterKeyFile != null) (pkgs.writeText \"wagon-wheels.conf\" \"\");\n")
+	b.WriteString("  };\n")
+	b.WriteString("}\n")
+
+	return os.WriteFile(n.OutPath, []byte(b.String()), 0o644)
+}
+
+func partitionByTarget(specs []FileSpec) (home, xdg, systemd []FileSpec) {
+	for _, s := range specs {
+		switch s.Target {
+		case TargetXDGConfig:
+			xdg = append(xdg, s)
+		case TargetSystemdUser:
+			systemd = append(systemd, s)
+		default:
+			home = append(home, s)
+		}
+	}
+	return
+}
+
+func writeFileBlock(b *strings.Builder, attr string, specs []FileSpec) {
+	if len(specs) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "    %s = {\n", attr)
+	for _, spec := range specs {
+		fmt.Fprintf(b, "      %q = {\n", spec.Name)
+		fmt.Fprintf(b, "        text = %s;\n", nixString(spec.Contents))
+		if spec.Mode&0o111 != 0 {
+			b.WriteString("        executable = true;\n")
+		}
+		b.WriteString("      };\n")
+	}
+	b.WriteString("    };\n\n")
+}
+
+// nixString renders s as a Nix indented string literal (”...”), which
+// tolerates embedded quotes and newlines without per-character escaping.
+func nixString(s string) string {
+	escaped := strings.ReplaceAll(s, "${", "''${")
+	return "''\n        " + strings.ReplaceAll(escaped, "\n", "\n        ") + "\n      ''"
+}
+
+func (n NixModuleEmitter) EmitServiceSpec(path string, spec platformspec.OmServiceSpec) error {
+	// The OmServiceSpec is still emitted as JSON-LD for deploy tooling to
+	// consume; it is also threaded into the Nix module as an informational
+	// comment so `nix eval` output is self-documenting.
+	return writeJSONLD(path, spec)
+}
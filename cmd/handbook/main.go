@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/exec"
+	"os/user"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/secrets"
+	"github.com/zebadiee/omarchy-ai-assist/pkg/platformspec"
 )
 
 const (
@@ -20,29 +28,86 @@ const (
 )
 
 func main() {
-	must(createDirs(
-		binDir, wagonDir, playbookDir, hyprConfigDir, systemdDir,
-		filepath.Join(projectRoot, "configs", "infra"),
-	))
-
-	must(writeFile(filepath.Join(binDir, "omai.js"), omaiJS, 0o755))
-	must(writeFile(filepath.Join(binDir, "omarchy-guide"), omarchyGuide, 0o755))
-	must(writeFile(filepath.Join(binDir, "wagon-handoff-custom"), wagonHandoffCustom, 0o755))
-	must(writeFile(filepath.Join(binDir, "wagon-handoff-maintenance"), wagonHandoffMaintenance, 0o755))
-	must(writeFile(filepath.Join(baseDir, "mouse_menu.sh"), mouseMenu, 0o755))
-	must(writeFile(filepath.Join(baseDir, "onboarding.sh"), onboardingSh, 0o755))
-	must(writeFile(filepath.Join(baseDir, "onboarding.service"), onboardingService, 0o644))
-	must(writeFile(filepath.Join(baseDir, ".env"), envTemplate, 0o600))
-	must(writeFile(filepath.Join(baseDir, "wagon-wheels.conf"), wagonConf, 0o644))
-	must(writeFile(filepath.Join(wagonDir, "room.json"), roomJSON, 0o644))
-
-	writePlaybook(playbookDir)
-
-	specPath := filepath.Join(projectRoot, "configs", "ai-token.jsonld")
+	emitMode := flag.String("emit", "fs", "generation backend: \"fs\" (write files directly) or \"nix\" (emit a home-manager module)")
+	nixOut := flag.String("nix-out", filepath.Join(projectRoot, "configs", "nix", "omarchy-wagon.nix"), "output path for --emit=nix")
+	fix := flag.Bool("fix", false, "with the \"verify\" subcommand, rewrite any drifted files instead of just reporting them")
+	servicesFrom := flag.String("services-from", "", "directory of additional *.yaml/*.yml/*.hcl Service manifests to merge into spec.services")
+	flag.Parse()
+
+	if flag.Arg(0) == "verify" {
+		os.Exit(runVerify(*fix))
+	}
+
+	specs := handbookFileSpecs()
 	spec := buildOmServiceSpec()
-	must(writeJSONLD(specPath, spec))
+	if *servicesFrom != "" {
+		extra, err := platformspec.LoadServicesFromDir(*servicesFrom)
+		must(err)
+		spec.Spec.Services = append(spec.Spec.Services, extra...)
+	}
+	if errs := platformspec.Validate(spec); len(errs) > 0 {
+		for _, e := range errs {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		log.Fatal("OmServiceSpec failed validation")
+	}
+
+	switch *emitMode {
+	case "fs":
+		must(createDirs(
+			binDir, wagonDir, playbookDir, hyprConfigDir, systemdDir,
+			filepath.Join(projectRoot, "configs", "infra"),
+		))
+		must(installOmaiBinary(filepath.Join(binDir, "omai")))
+		must(installRoomBinary(filepath.Join(binDir, "room")))
+		must(installHandbookBinary(filepath.Join(binDir, "handbook")))
+
+		emitter := FilesystemEmitter{}
+		must(emitter.EmitFiles(specs))
+		must(emitter.EmitServiceSpec(filepath.Join(projectRoot, "configs", "ai-token.jsonld"), spec))
+		must(writeSopsConfig())
+		encryptSecretsEnv()
+
+		fmt.Println("Handbook files and OmServiceSpec have been generated. Review before deploying.")
+
+	case "nix":
+		must(os.MkdirAll(filepath.Dir(*nixOut), 0o755))
+		emitter := NixModuleEmitter{OutPath: *nixOut}
+		must(emitter.EmitFiles(specs))
+		must(emitter.EmitServiceSpec(filepath.Join(projectRoot, "configs", "ai-token.jsonld"), spec))
+
+		fmt.Printf("home-manager module written to %s. Import it and set programs.omarchyWagon.enable = true.\n", *nixOut)
+
+	default:
+		log.Fatalf("unknown --emit value %q (want \"fs\" or \"nix\")", *emitMode)
+	}
+}
+
+// handbookFileSpecs enumerates every generated artifact in backend-neutral
+// form, so FilesystemEmitter and NixModuleEmitter render the same data.
+func handbookFileSpecs() []FileSpec {
+	specs := []FileSpec{
+		{Name: "bin/omarchy-guide", Target: TargetHome, Contents: omarchyGuide, Mode: 0o755},
+		{Name: "bin/wagon-handoff-custom", Target: TargetHome, Contents: wagonHandoffCustom, Mode: 0o755},
+		{Name: "bin/wagon-handoff-maintenance", Target: TargetHome, Contents: wagonHandoffMaintenance, Mode: 0o755},
+		{Name: "mouse_menu.sh", Target: TargetHome, Contents: mouseMenu, Mode: 0o755},
+		{Name: "onboarding.sh", Target: TargetHome, Contents: onboardingSh, Mode: 0o755},
+		{Name: "onboarding.service", Target: TargetSystemdUser, Contents: onboardingService, Mode: 0o644},
+		{Name: "secrets.env.enc", Target: TargetHome, Contents: envTemplate, Mode: 0o600},
+		{Name: "wagon-wheels.conf", Target: TargetHome, Contents: wagonConf, Mode: 0o644},
+	}
+
+	playbook := map[string]string{
+		"01-theming.md":  playbookTheming,
+		"02-packages.md": playbookPackages,
+		"03-inputs.md":   playbookInputs,
+		"04-updating.md": playbookUpdating,
+	}
+	for name, body := range playbook {
+		specs = append(specs, FileSpec{Name: filepath.Join("playbook", name), Target: TargetHome, Contents: body, Mode: 0o644})
+	}
 
-	fmt.Println("Handbook files and OmServiceSpec have been generated. Review before deploying.")
+	return specs
 }
 
 func createDirs(paths ...string) error {
@@ -64,156 +129,89 @@ func writeFile(path, contents string, mode os.FileMode) error {
 	return nil
 }
 
-func writeJSONLD(path string, data any) error {
-	b, err := json.MarshalIndent(data, "", "  ")
+// installOmaiBinary compiles cmd/omai and installs it at dest, replacing
+// the old approach of writing out a Node.js shim (omai.js) that required a
+// runtime `node` dependency.
+func installOmaiBinary(dest string) error {
+	return installGoBinary("github.com/zebadiee/omarchy-ai-assist/cmd/omai", dest)
+}
+
+// installRoomBinary compiles cmd/room, the backend for
+// `omarchy-guide room {tail,search,summarize,clear}`.
+func installRoomBinary(dest string) error {
+	return installGoBinary("github.com/zebadiee/omarchy-ai-assist/cmd/room", dest)
+}
+
+// installHandbookBinary compiles this generator itself into binDir, so the
+// shell wrapper's `doctor`/`verify` commands can re-invoke it to reconcile
+// drift without the user needing `go run` or a source checkout on hand.
+func installHandbookBinary(dest string) error {
+	return installGoBinary("github.com/zebadiee/omarchy-ai-assist/cmd/handbook", dest)
+}
+
+func installGoBinary(pkg, dest string) error {
+	cmd := exec.Command("go", "build", "-o", dest, pkg)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to build %s: %w", pkg, err)
+	}
+	return os.Chmod(dest, 0o755)
+}
+
+// writeSopsConfig writes a .sops.yaml next to configs/ with a creation
+// rule scoped to this host and user, matching the multi-recipient pattern
+// common in dotfiles-as-Nix setups. Recipients come from
+// OMAI_SOPS_AGE_RECIPIENTS / OMAI_SOPS_PGP_FINGERPRINTS (comma-separated)
+// so this stays reproducible across machines instead of hard-coding keys.
+func writeSopsConfig() error {
+	host, err := os.Hostname()
 	if err != nil {
-		return err
+		host = "unknown-host"
 	}
-	return writeFile(path, string(b)+"\n", 0o644)
+	u, err := user.Current()
+	username := "unknown-user"
+	if err == nil {
+		username = u.Username
+	}
+
+	ageRecipients := splitNonEmpty(os.Getenv("OMAI_SOPS_AGE_RECIPIENTS"))
+	pgpFingerprints := splitNonEmpty(os.Getenv("OMAI_SOPS_PGP_FINGERPRINTS"))
+
+	rules := secrets.CreationRulesForHostUser(host, username, ageRecipients, pgpFingerprints)
+	return secrets.WriteSopsConfig(filepath.Join(projectRoot, "configs", ".sops.yaml"), rules)
 }
 
-func writePlaybook(dir string) {
-	files := map[string]string{
-		"01-theming.md":  playbookTheming,
-		"02-packages.md": playbookPackages,
-		"03-inputs.md":   playbookInputs,
-		"04-updating.md": playbookUpdating,
+func splitNonEmpty(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+// encryptSecretsEnv encrypts secrets.env.enc in place when sops is
+// available; otherwise it leaves the plaintext template and warns, since
+// without sops or configured recipients there is nothing to encrypt to.
+func encryptSecretsEnv() {
+	path := filepath.Join(baseDir, "secrets.env.enc")
+	if !secrets.Available() {
+		log.Printf("warning: sops not found on PATH; %s was written as plaintext, encrypt it manually", path)
+		return
 	}
-	for name, body := range files {
-		must(writeFile(filepath.Join(dir, name), body, 0o644))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := secrets.EncryptInPlace(ctx, path); err != nil {
+		log.Printf("warning: failed to encrypt %s: %v", path, err)
 	}
 }
 
-var omaiJS = strings.Join([]string{
-	"#!/usr/bin/env node",
-	"const fetch = require('node-fetch');",
-	"const dotenv = require('dotenv');",
-	"const fs = require('fs');",
-	"const readline = require('readline');",
-	"",
-	"const ENV_PATH = process.env.OMAI_ENV_PATH || (process.env.HOME + '/.npm-global/bin/.env');",
-	"const ROOM_FILE = process.env.OMAI_ROOM_FILE || (process.env.HOME + '/.npm-global/omarchy-wagon/room.json');",
-	"",
-	"dotenv.config({ path: ENV_PATH });",
-	"",
-	"const endpoint = process.env.OR_ENDPOINT || 'https://openrouter.ai/api/v1/chat/completions';",
-	"const model = process.env.OR_MODEL || 'deepseek/deepseek-r1-0528-qwen3-8b';",
-	"const apiKey = process.env.OPENROUTER_API_KEY;",
-	"",
-	"if (!apiKey) {",
-	"  console.error('Set OPENROUTER_API_KEY in your env or .env file.');",
-	"  process.exit(1);",
-	"}",
-	"",
-	"const args = process.argv.slice(2);",
-	"const handoff = args.includes('--handoff');",
-	"",
-	"let lang = null;",
-	"const promptArgs = [];",
-	"",
-	"for (let i = 0; i < args.length; i++) {",
-	"  const arg = args[i];",
-	"  if (arg === '--handoff') {",
-	"    continue;",
-	"  }",
-	"  if (arg === '--lang') {",
-	"    if (i + 1 >= args.length) {",
-	"      console.error('Missing language for --lang flag.');",
-	"      process.exit(1);",
-	"    }",
-	"    lang = args[i + 1];",
-	"    i++;",
-	"    continue;",
-	"  }",
-	"  promptArgs.push(arg);",
-	"}",
-	"",
-	"let systemPrompt = 'You are an Omarchy customization copilot.';",
-	"if (lang) {",
-	"  systemPrompt = 'You are an expert ' + lang + ' programmer. Translate the user\'s request into a complete, correct, and\n  idiomatic ' + lang + ' program.';",
-	"}",
-	"",
-	"const messages = [{ role: 'system', content: systemPrompt }];",
-	"",
-	"async function ask(prompt) {",
-	"  messages.push({ role: 'user', content: prompt });",
-	"",
-	"  const body = { model, messages };",
-	"",
-	"  const res = await fetch(endpoint, {",
-	"    method: 'POST',",
-	"    headers: {",
-	"      Authorization: `Bearer ${apiKey}`,",
-	"      'HTTP-Referer': process.env.OR_REFERER || 'https://omarchy.local',",
-	"      'X-Title': process.env.OR_TITLE || 'Omarchy Wagon Wheels',",
-	"      'Content-Type': 'application/json',",
-	"    },",
-	"    body: JSON.stringify(body),",
-	"  });",
-	"",
-	"  if (!res.ok) {",
-	"    console.error(`OpenRouter error: ${res.status} ${await res.text()}`);",
-	"    return;",
-	"  }",
-	"",
-	"  const data = await res.json();",
-	"  const summary = data.choices?.[0]?.message?.content?.trim() || '(No content)';",
-	"  console.log('\n' + summary + '\n');",
-	"  messages.push({ role: 'assistant', content: summary });",
-	"",
-	"  if (handoff) {",
-	"    updateRoom(summary, lang);",
-	"  }",
-	"}",
-	"",
-	"function updateRoom(summary, langArg) {",
-	"  let roomData = { last_update: new Date().toISOString(), context: [] };",
-	"  try {",
-	"    if (fs.existsSync(ROOM_FILE)) {",
-	"      roomData = JSON.parse(fs.readFileSync(ROOM_FILE, 'utf-8'));",
-	"    }",
-	"  } catch (err) {",
-	"    console.warn('Failed to read existing room file, resetting context:', err);",
-	"  }",
-	"  const topic = langArg ? 'translation:' + langArg : 'customization';",
-	"  roomData.context = roomData.context || [];",
-	"  roomData.context.push({ source: 'omai', topic, summary });",
-	"  roomData.last_update = new Date().toISOString();",
-	"",
-	"  try {",
-	"    fs.mkdirSync(require('path').dirname(ROOM_FILE), { recursive: true });",
-	"    fs.writeFileSync(ROOM_FILE, JSON.stringify(roomData, null, 2));",
-	"  } catch (err) {",
-	"    console.error('Failed to update room context:', err);",
-	"  }",
-	"}",
-	"",
-	"const prompt = promptArgs.join(' ');",
-	"",
-	"if (prompt) {",
-	"  ask(prompt).then(() => process.exit(0));",
-	"}",
-	" else {",
-	"  const rl = readline.createInterface({",
-	"    input: process.stdin,",
-	"    output: process.stdout,",
-	"  });",
-	"",
-	"  console.log('Entering chat mode. Type "exit" or "quit" to end the conversation.');",
-	"",
-	"  function chatLoop() {",
-	"    rl.question('You: ', async (userInput) => {",
-	"      if (userInput.toLowerCase() === 'exit' || userInput.toLowerCase() === 'quit') {",
-	"        rl.close();",
-	"        return;",
-	"      }",
-	"      await ask(userInput);",
-	"      chatLoop();",
-	"    });",
-	"  }",
-	"  chatLoop();",
-	"}",
-}, "\n")
+func writeJSONLD(path string, data any) error {
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(path, string(b)+"\n", 0o644)
+}
 
 var omarchyGuide = strings.Join([]string{
 	"#!/bin/bash",
@@ -221,7 +219,7 @@ var omarchyGuide = strings.Join([]string{
 	"",
 	"show_journey() {",
 	"  echo \"Welcome to the Omarchy Wagon Wheels Journey\"",
-	"  echo "",
+	"  echo \"\"",
 	"  echo \"1. Orientation: Learn the basics of the Omarchy desktop.\"",
 	"  echo \"2. Install: Set up the Wagon Wheels layer.\"",
 	"  echo \"3. First Hour: Get comfortable with the keyboard-driven workflow.\"",
@@ -230,84 +228,124 @@ var omarchyGuide = strings.Join([]string{
 	"  echo \"6. Rescue: Learn how to recover from issues.\"",
 	"}",
 	"",
-	toggle_ui() {
-	  local config_source="$HOME/.npm-global/wagon-wheels.conf"
-	  local config_dest_dir="$HOME/.config/hypr/conf.d"
-	  local config_dest="$config_dest_dir/wagon-wheels.conf"
-
-	  if [ -L "$config_dest" ]; then
-	    rm "$config_dest"
-	    echo "Wagon Wheels UI helpers disabled."
-	  else
-	    mkdir -p "$config_dest_dir"
-	    ln -s "$config_source" "$config_dest"
-	    echo "Wagon Wheels UI helpers enabled."
-	  fi
-	}
-
-	playbook() {
-	  local playbook_dir="$HOME/.npm-global/playbook"
-	  if [ ! -d "$playbook_dir" ]; then
-	    echo "Error: Playbook directory not found at $playbook_dir"
-	    exit 1
-	  fi
-
-	  case "${2:-}" in
-	    list)
-	      ls -1 "$playbook_dir"
-	      ;;
-	    show)
-	      if [ -z "${3:-}" ]; then
-	        echo "Usage: $0 playbook show <playbook_entry>"
-	        exit 1
-	      fi
-	      if [ -f "$playbook_dir/$3" ]; then
-	        cat "$playbook_dir/$3"
-	      else
-	        echo "Playbook entry '$3' not found."
-	      fi
-	      ;;
-	    *)
-	      echo "Usage: $0 {journey|toggle-ui|playbook|room|doctor}"
-	      exit 1
-	      ;;
-	  esac
-	}
-
-	room() {
-	  local room_file="$HOME/.npm-global/omarchy-wagon/room.json"
-	  if [ -f "$room_file" ]; then
-	    cat "$room_file"
-	  else
-	    echo "The breakout room is empty."
-	  fi
-	}
-
-	doctor() {
-	  echo "Checking essential dependencies..."
-	  local missing_deps=()
-	  for dep in wofi tmux node; do
-	    if ! command -v "$dep" &>/dev/null; then
-	      missing_deps+=("$dep")
-	    fi
-	  done
-
-	  if [ ${#missing_deps[@]} -eq 0 ]; then
-	    echo "All essential dependencies are installed."
-	  else
-	    echo "Warning: Missing dependencies: ${missing_deps[*]}"
-	    echo "Please install them for full functionality."
-	  fi
-	}
-
-	case "${1:-}" in
-	  journey)    show_journey ;;
-	  toggle-ui)  toggle_ui ;;
-	  playbook)   playbook "$@" ;;
-	  room)       room ;;
-	  doctor)     doctor ;;
-	  *)          echo "Usage: $0 {journey|toggle-ui|playbook|room|doctor}"; exit 1 ;;
-	esac
+	"toggle_ui() {",
+	"  local config_source=\"$HOME/.npm-global/wagon-wheels.conf\"",
+	"  local config_dest_dir=\"$HOME/.config/hypr/conf.d\"",
+	"  local config_dest=\"$config_dest_dir/wagon-wheels.conf\"",
+	"",
+	"  if [ -L \"$config_dest\" ]; then",
+	"    rm \"$config_dest\"",
+	"    echo \"Wagon Wheels UI helpers disabled.\"",
+	"  else",
+	"    mkdir -p \"$config_dest_dir\"",
+	"    ln -s \"$config_source\" \"$config_dest\"",
+	"    echo \"Wagon Wheels UI helpers enabled.\"",
+	"  fi",
+	"}",
+	"",
+	"playbook() {",
+	"  local playbook_dir=\"$HOME/.npm-global/playbook\"",
+	"  if [ ! -d \"$playbook_dir\" ]; then",
+	"    echo \"Error: Playbook directory not found at $playbook_dir\"",
+	"    exit 1",
+	"  fi",
+	"",
+	"  case \"${2:-}\" in",
+	"    list)",
+	"      ls -1 \"$playbook_dir\"",
+	"      ;;",
+	"    show)",
+	"      if [ -z \"${3:-}\" ]; then",
+	"        echo \"Usage: $0 playbook show <playbook_entry>\"",
+	"        exit 1",
+	"      fi",
+	"      if [ -f \"$playbook_dir/$3\" ]; then",
+	"        cat \"$playbook_dir/$3\"",
+	"      else",
+	"        echo \"Playbook entry '$3' not found.\"",
+	"      fi",
+	"      ;;",
+	"    *)",
+	"      echo \"Usage: $0 {journey|toggle-ui|playbook|room|doctor}\"",
+	"      exit 1",
+	"      ;;",
+	"  esac",
+	"}",
+	"",
+	"room() {",
+	"  local bin_dir=\"$HOME/.npm-global/bin\"",
+	"  case \"${2:-}\" in",
+	"    tail|search|summarize|clear) \"$bin_dir/room\" \"${@:2}\" ;;",
+	"    \"\") \"$bin_dir/room\" tail ;;",
+	"    *)  echo \"Usage: $0 room {tail [n]|search <query>|summarize|clear}\"; exit 1 ;;",
+	"  esac",
+	"}",
+	"",
+	"doctor() {",
+	"  echo \"Checking essential dependencies...\"",
+	"  local missing_deps=()",
+	"  for dep in wofi tmux; do",
+	"    if ! command -v \"$dep\" &>/dev/null; then",
+	"      missing_deps+=(\"$dep\")",
+	"    fi",
+	"  done",
+	"",
+	"  if [ ${#missing_deps[@]} -eq 0 ]; then",
+	"    echo \"All essential dependencies are installed.\"",
+	"  else",
+	"    echo \"Warning: Missing dependencies: ${missing_deps[*]}\"",
+	"    echo \"Please install them for full functionality.\"",
+	"  fi",
+	"",
+	"  echo \"\"",
+	"  echo \"Verifying generated environment...\"",
+	"  verify \"$@\"",
+	"}",
+	"",
+	"verify() {",
+	"  local fix_flag=\"\"",
+	"  if [ \"${2:-}\" = \"--fix\" ]; then",
+	"    fix_flag=\"--fix\"",
+	"  fi",
+	"  \"$HOME/.npm-global/bin/handbook\" verify $fix_flag",
+	"}",
+	"",
+	"wagon_secrets() {",
+	"  local secrets_file=\"$HOME/.npm-global/secrets.env.enc\"",
+	"  if ! command -v sops &>/dev/null; then",
+	"    echo \"Error: sops is not installed. Install it to manage encrypted secrets.\" >&2",
+	"    exit 1",
+	"  fi",
+	"",
+	"  case \"${2:-}\" in",
+	"    edit)",
+	"      sops \"$secrets_file\"",
+	"      ;;",
+	"    decrypt)",
+	"      sops -d \"$secrets_file\"",
+	"      ;;",
+	"    rotate)",
+	"      sops rotate -i \"$secrets_file\"",
+	"      ;;",
+	"    *)",
+	"      echo \"Usage: $0 wagon-secrets {edit|decrypt|rotate}\"",
+	"      exit 1",
+	"      ;;",
+	"  esac",
+	"",
+	"  systemctl --user try-reload-or-restart onboarding.service 2>/dev/null || true",
+	"}",
+	"",
+	"case \"${1:-}\" in",
+	"  journey)        show_journey ;;",
+	"  toggle-ui)      toggle_ui ;;",
+	"  playbook)       playbook \"$@\" ;;",
+	"  room)           room ;;",
+	"  doctor)         doctor \"$@\" ;;",
+	"  verify)         verify \"$@\" ;;",
+	"  wagon-secrets)  wagon_secrets \"$@\" ;;",
+	"  *)              echo \"Usage: $0 {journey|toggle-ui|playbook|room|doctor|verify|wagon-secrets}\"; exit 1 ;;",
+	"esac",
 }, "\n")
 
 var wagonHandoffCustom = strings.Join([]string{
@@ -315,8 +353,8 @@ var wagonHandoffCustom = strings.Join([]string{
 	"set -euo pipefail",
 	"",
 	"BIN_DIR=\"$(dirname \"$0\")\"",
-	"if ! command -v node &>/dev/null; then",
-	"  echo \"Error: node is not installed. Please install Node.js to run this script.\" >&2",
+	"if ! command -v \"$BIN_DIR/omai\" &>/dev/null; then",
+	"  echo \"Error: $BIN_DIR/omai is missing. Re-run the handbook generator.\" >&2",
 	"  exit 1",
 	"fi",
 	"",
@@ -325,7 +363,7 @@ var wagonHandoffCustom = strings.Join([]string{
 	"  PROMPT=\"Help me customize Omarchy\"",
 	"fi",
 	"",
-	"node \"$BIN_DIR/omai.js\" \"$PROMPT\" --handoff",
+	"\"$BIN_DIR/omai\" --handoff \"$PROMPT\"",
 }, "\n")
 
 var wagonHandoffMaintenance = strings.Join([]string{
@@ -352,7 +390,7 @@ var onboardingSh = strings.Join([]string{
 	"",
 	"clear",
 	"echo \"Welcome to Omarchy!\"",
-	"echo "",
+	"echo \"\"",
 	"echo \"Here are some essential keyboard shortcuts to get you started:\"",
 	"echo \"  Super + Space: Application Launcher\"",
 	"echo \"  Super + Alt + Space: Omarchy Menu\"",
@@ -396,16 +434,6 @@ var wagonConf = strings.Join([]string{
 	"bind = , mouse:273, exec, /home/zebadiee/.npm-global/mouse_menu.sh",
 }, "\n")
 
-var roomJSON = strings.Join([]string{
-	"{",
-	"  \"last_update\": \"2025-02-14T10:15:00Z\"",
-	"  \"context\": [
-	"    {\"source\": \"omassistant\", \"topic\": \"maintenance\", \"summary\": \"...\"}",
-	"    {\"source\": \"omai\", \"topic\": \"customization\", \"summary\": \"...\"}",
-	"  ]",
-	"}",
-}, "\n")
-
 var playbookTheming = strings.Join([]string{
 	"# 1. Theming in Omarchy",
 	"",
@@ -500,54 +528,49 @@ var playbookUpdating = strings.Join([]string{
 	" omarchy-snapshot restore <name>",
 }, "\n")
 
-func buildOmServiceSpec() map[string]any {
-	return map[string]any{
-		"apiVersion": "platformspec/v1",
-		"kind":       "OmServiceSpec",
-		"metadata": map[string]any{
-			"name": "ai-token-service",
-		},
-		"spec": map[string]any{
-			"services": []any{
-				map[string]any{
-					"name": "token-budgets-api",
-					"type": "container",
-					"runtime": map[string]any{
-						"image":     "registry.example.com/ai-token-manager:latest",
-						"command":   []string{"python", "-m", "src.engine.server"},
-						"replicas":  2,
-						"resources": map[string]any{"cpu": "500m", "memory": "512Mi"},
-						"environment": []any{
-							map[string]any{"name": "TOKEN_BUDGET_FILE", "value": "configs/infra/token_budgets.toml"},
+// buildOmServiceSpec builds the typed OmServiceSpec this repo ships by
+// default. --services-from appends further platformspec.Service entries
+// on top of whatever this returns.
+func buildOmServiceSpec() platformspec.OmServiceSpec {
+	return platformspec.OmServiceSpec{
+		APIVersion: "platformspec/v1",
+		Kind:       "OmServiceSpec",
+		Metadata:   platformspec.Metadata{Name: "ai-token-service"},
+		Spec: platformspec.Spec{
+			Services: []platformspec.Service{
+				{
+					Name: "token-budgets-api",
+					Type: "container",
+					Runtime: platformspec.Runtime{
+						Image:     "registry.example.com/ai-token-manager:latest",
+						Command:   []string{"python", "-m", "src.engine.server"},
+						Replicas:  2,
+						Resources: map[string]string{"cpu": "500m", "memory": "512Mi"},
+						Environment: []platformspec.EnvVar{
+							{Name: "TOKEN_BUDGET_FILE", Value: "configs/infra/token_budgets.toml"},
 						},
-						"secrets": []any{
-							map[string]any{"name": "OPENROUTER_API_KEY", "secretRef": "openrouter/api-key"},
+						Secrets: []platformspec.SecretRef{
+							{Name: "OPENROUTER_API_KEY", SecretRef: "sops://configs/secrets.env.enc#OPENROUTER_API_KEY"},
 						},
 					},
-					"exposure": map[string]any{
-						"port":    8080,
-						"ingress": map[string]any{"host": "token-manager.omarchy.local"},
+					Exposure: platformspec.Exposure{
+						Port:    8080,
+						Ingress: &platformspec.Ingress{Host: "token-manager.omarchy.local"},
 					},
-					"dependencies": []any{
-						map[string]any{"name": "om-db", "type": "postgresql", "service": "postgres-prod"},
+					Dependencies: []platformspec.Dependency{
+						{Name: "om-db", Type: "postgresql", Service: "postgres-prod"},
 					},
-					"observability": map[string]any{
-						"logs":    map[string]any{"sink": "om-logs/default"},
-						"metrics": map[string]any{"dashboard": "grafana/token-mgr"},
+					Observability: platformspec.Observability{
+						Logs:    &platformspec.LogSink{Sink: "om-logs/default"},
+						Metrics: &platformspec.MetricsSink{Dashboard: "grafana/token-mgr"},
 					},
 				},
 			},
-			"policies": map[string]any{
-				"tokenBudgets": map[string]any{
-					"dailyLimit":     200000,
-					"alertThreshold": 0.9,
-				},
+			Policies: platformspec.Policies{
+				TokenBudgets: platformspec.TokenBudgets{DailyLimit: 200000, AlertThreshold: 0.9},
 			},
-			"webhooks": []any{
-				map[string]any{
-					"event": "deploy.success",
-					"url":   "https://ops.example.com/hooks/ai-token",
-				},
+			Webhooks: []platformspec.Webhook{
+				{Event: "deploy.success", URL: "https://ops.example.com/hooks/ai-token"},
 			},
 		},
 	}
@@ -557,4 +580,4 @@ func must(err error) {
 	if err != nil {
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/omai"
+	"github.com/zebadiee/omarchy-ai-assist/pkg/platformspec"
+)
+
+// runVerify turns the generator into an idempotent reconciler: it
+// reparses every emitted file, diffs it against what's on disk, validates
+// the OmServiceSpec, checks the systemd unit, and pings the configured omai
+// provider. It returns the process exit code (0 on success), matching the
+// shell `omarchy-guide doctor` mirror so both are usable in CI/pre-deploy
+// hooks.
+func runVerify(fix bool) int {
+	ok := true
+
+	drifted, err := checkFiles(handbookFileSpecs(), fix)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "verify: %v\n", err)
+		ok = false
+	}
+	for _, name := range drifted {
+		status := "drifted"
+		if fix {
+			status = "drifted (rewritten)"
+		} else {
+			ok = false
+		}
+		fmt.Printf("  [%s] %s\n", status, name)
+	}
+	if len(drifted) == 0 {
+		fmt.Println("  all generated files match what's on disk")
+	}
+
+	if errs := platformspec.Validate(buildOmServiceSpec()); len(errs) > 0 {
+		ok = false
+		for _, e := range errs {
+			fmt.Printf("  [spec] %s\n", e)
+		}
+	} else {
+		fmt.Println("  OmServiceSpec is valid")
+	}
+
+	switch err := checkSystemdUnit(filepath.Join(systemdDir, "onboarding.service")); {
+	case err == errSystemdAnalyzeUnavailable:
+		fmt.Println("  [systemd] systemd-analyze not on PATH, skipping")
+	case err != nil:
+		ok = false
+		fmt.Printf("  [systemd] %s\n", err)
+	default:
+		fmt.Println("  systemd unit verifies")
+	}
+
+	provider := omai.LoadConfigFromEnv().Provider
+	if err := checkProviderConnectivity(); err != nil {
+		ok = false
+		fmt.Printf("  [%s] %s\n", provider, err)
+	} else {
+		fmt.Printf("  %s is reachable\n", provider)
+	}
+
+	if !ok {
+		fmt.Println("verify: one or more checks failed")
+		return 1
+	}
+	fmt.Println("verify: environment is healthy")
+	return 0
+}
+
+// checkFiles compares every FileSpec's expected contents against what is
+// actually on disk. With fix=true, drifted files are rewritten in place.
+func checkFiles(specs []FileSpec, fix bool) ([]string, error) {
+	emitter := FilesystemEmitter{}
+	var drifted []string
+	for _, spec := range specs {
+		path := filepath.Join(emitter.rootFor(spec.Target), spec.Name)
+		current, err := os.ReadFile(path)
+		if err != nil {
+			drifted = append(drifted, spec.Name+" (missing)")
+			if fix {
+				if err := emitter.EmitFiles([]FileSpec{spec}); err != nil {
+					return drifted, err
+				}
+			}
+			continue
+		}
+		if !bytes.Equal(current, []byte(spec.Contents)) {
+			drifted = append(drifted, spec.Name)
+			if fix {
+				if err := emitter.EmitFiles([]FileSpec{spec}); err != nil {
+					return drifted, err
+				}
+			}
+		}
+	}
+	return drifted, nil
+}
+
+// errSystemdAnalyzeUnavailable marks the "not applicable" case: no
+// systemd-analyze on PATH, which isn't a verification failure (plenty of
+// dev/CI environments don't have systemd at all).
+var errSystemdAnalyzeUnavailable = errors.New("systemd-analyze not on PATH")
+
+// checkSystemdUnit shells out to `systemd-analyze verify`, which catches
+// unit-file syntax errors without actually starting the service.
+func checkSystemdUnit(path string) error {
+	if _, err := exec.LookPath("systemd-analyze"); err != nil {
+		return errSystemdAnalyzeUnavailable
+	}
+	out, err := exec.Command("systemd-analyze", "verify", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("systemd-analyze verify failed: %s", string(out))
+	}
+	return nil
+}
+
+// defaultProviderEndpoint mirrors each Provider constructor's own
+// endpoint-if-unset default, so checkProviderConnectivity pings the same
+// host the configured provider will actually talk to.
+func defaultProviderEndpoint(provider string) string {
+	switch provider {
+	case "", "openrouter":
+		return omai.DefaultOpenRouterEndpoint
+	case "ollama":
+		return omai.DefaultOllamaEndpoint
+	case "anthropic":
+		return omai.DefaultAnthropicEndpoint
+	case "openai":
+		return omai.DefaultOpenAIEndpoint
+	default:
+		return ""
+	}
+}
+
+// checkProviderConnectivity issues a HEAD request against whichever
+// provider OMAI_PROVIDER (or its default, openrouter) actually configures,
+// so `verify` catches network/DNS/TLS problems before a real deploy does.
+func checkProviderConnectivity() error {
+	cfg := omai.LoadConfigFromEnv()
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = defaultProviderEndpoint(cfg.Provider)
+	}
+	if endpoint == "" {
+		return fmt.Errorf("no known endpoint for provider %q", cfg.Provider)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,161 @@
+// Command omai is the native Go replacement for the old omai.js shim. It
+// is what the generator in cmd/handbook installs into binDir, and what
+// wagon-handoff-custom now execs instead of `node omai.js`.
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/omai"
+	"github.com/zebadiee/omarchy-ai-assist/internal/room"
+)
+
+func main() {
+	var (
+		handoff = flag.Bool("handoff", false, "record the exchange into the breakout-room context")
+		lang    = flag.String("lang", "", "translate the prompt into an idiomatic program in this language")
+	)
+	flag.Parse()
+
+	cfg := omai.LoadConfigFromEnv()
+	provider, err := omai.NewProvider(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := omai.NewClient(provider, omai.DefaultRetryPolicy())
+
+	systemPrompt := "You are an Omarchy customization copilot."
+	if *lang != "" {
+		systemPrompt = fmt.Sprintf("You are an expert %s programmer. Translate the user's request into a complete, correct, and idiomatic %s program.", *lang, *lang)
+	}
+
+	session := &session{
+		client:   client,
+		model:    cfg.Model,
+		messages: []omai.Message{{Role: omai.RoleSystem, Content: systemPrompt}},
+	}
+
+	var roomLog *room.Room
+	if *handoff {
+		roomLog = openRoom()
+	}
+
+	prompt := strings.Join(flag.Args(), " ")
+	if prompt != "" {
+		if err := session.ask(prompt); err != nil {
+			log.Fatal(err)
+		}
+		if roomLog != nil {
+			recordHandoff(roomLog, client, cfg.Model, prompt, *lang, session.lastSummary)
+		}
+		return
+	}
+
+	fmt.Println("Entering chat mode. Type \"exit\" or \"quit\" to end the conversation.")
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		fmt.Print("You: ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		if line == "exit" || line == "quit" {
+			return
+		}
+		if err := session.ask(line); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		if roomLog != nil {
+			recordHandoff(roomLog, client, cfg.Model, line, *lang, session.lastSummary)
+		}
+	}
+}
+
+// session tracks the running conversation, mirroring the in-memory
+// `messages` array the JS shim kept across turns.
+type session struct {
+	client      *omai.Client
+	model       string
+	messages    []omai.Message
+	lastSummary string
+}
+
+func (s *session) ask(prompt string) error {
+	s.messages = append(s.messages, omai.Message{Role: omai.RoleUser, Content: prompt})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	resp, err := s.client.Complete(ctx, omai.Request{Model: s.model, Messages: s.messages})
+	if err != nil {
+		return err
+	}
+
+	summary := strings.TrimSpace(resp.Content)
+	if summary == "" {
+		summary = "(No content)"
+	}
+	fmt.Printf("\n%s\n\n", summary)
+	s.messages = append(s.messages, omai.Message{Role: omai.RoleAssistant, Content: summary})
+	s.lastSummary = summary
+	return nil
+}
+
+// openRoom opens the JSONL breakout-room log that replaced the old
+// room.json blob. OMAI_ROOM_DIR overrides the directory for testing.
+func openRoom() *room.Room {
+	dir := os.Getenv("OMAI_ROOM_DIR")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".npm-global", "omarchy-wagon")
+	}
+	r, err := room.Open(dir, room.DefaultBudget())
+	if err != nil {
+		log.Printf("failed to open breakout-room log: %v", err)
+		return nil
+	}
+	return r
+}
+
+// estimateTokens is a rough 4-chars-per-token heuristic; not every
+// provider we support returns real usage accounting, so the room log's
+// token budgeting works off this estimate rather than nothing at all.
+func estimateTokens(s string) int {
+	return (len(s) + 3) / 4
+}
+
+func recordHandoff(r *room.Room, client *omai.Client, model, prompt, lang, summary string) {
+	if summary == "" {
+		return
+	}
+	topic := "customization"
+	if lang != "" {
+		topic = "translation:" + lang
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	entry := room.Entry{
+		Source:     "omai",
+		Topic:      topic,
+		TokensIn:   estimateTokens(prompt),
+		TokensOut:  estimateTokens(summary),
+		Model:      model,
+		PromptHash: room.HashPrompt(prompt),
+		Summary:    summary,
+	}
+	if err := r.Append(ctx, client, model, entry); err != nil {
+		log.Printf("failed to update room context: %v", err)
+	}
+}
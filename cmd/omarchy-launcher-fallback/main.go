@@ -0,0 +1,1089 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/chzyer/readline"
+	launcherdbus "github.com/zebadiee/omarchy-ai-assist/internal/dbus"
+	"github.com/zebadiee/omarchy-ai-assist/internal/hotkey"
+	"github.com/zebadiee/omarchy-ai-assist/internal/launcherconfig"
+	"github.com/zebadiee/omarchy-ai-assist/internal/pkgmgr"
+	"github.com/zebadiee/omarchy-ai-assist/internal/sandbox"
+)
+
+// OmarchyLauncher represents the main desktop launcher system with fallback interface
+type OmarchyLauncher struct {
+	configPath string
+	actions    []LauncherAction
+	lastUpdate time.Time
+	pkgBackend pkgmgr.PackageBackend
+	repl       *REPL
+	config     launcherconfig.Config
+}
+
+// LauncherAction represents an action available in the launcher
+type LauncherAction struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Icon        string                 `json:"icon"`
+	Category    string                 `json:"category"`
+	Command     string                 `json:"command"`
+	Args        []string               `json:"args"`
+	Hotkey      string                 `json:"hotkey"`
+	Priority    int                    `json:"priority"`
+	Sandbox     sandbox.SandboxProfile `json:"sandbox,omitempty"`
+}
+
+// NewOmarchyLauncher creates a new launcher instance
+func NewOmarchyLauncher() *OmarchyLauncher {
+	homeDir, _ := os.UserHomeDir()
+	configPath := filepath.Join(homeDir, ".config", "omarchy", "launcher")
+
+	return &OmarchyLauncher{
+		configPath: configPath,
+		lastUpdate: time.Now(),
+	}
+}
+
+// Initialize sets up the launcher with default actions
+func (ol *OmarchyLauncher) Initialize() error {
+	// Ensure config directory exists
+	if err := os.MkdirAll(ol.configPath, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+	return ol.reloadActions()
+}
+
+// reloadActions rebuilds ol.actions from the built-in defaults, the
+// detected package-manager backend, and actions.d/*.toml, in that order.
+// It's the Initialize body factored out so SIGHUP can re-run the same
+// pipeline to pick up edited config files without restarting the daemon.
+func (ol *OmarchyLauncher) reloadActions() error {
+	actions := defaultActions()
+
+	// Register package-manager actions for the host's native backend
+	// (pacman/apt/dnf/apk), plus flatpak as a universal overlay. A host
+	// whose distro isn't recognized just gets the flatpak actions.
+	if backend, err := pkgmgr.DetectHost(); err == nil {
+		ol.pkgBackend = backend
+		actions = append(actions, pkgActions(backend, "pkg")...)
+	} else {
+		fmt.Printf("⚠️  Package manager detection failed, flatpak-only: %v\n", err)
+	}
+	actions = append(actions, pkgActions(pkgmgr.FlatpakBackend{}, "pkg-flatpak")...)
+
+	// Layer declarative overrides from actions.d/*.toml over the built-in
+	// defaults above, the way NixOS modules layer option overrides rather
+	// than requiring the generator itself to be edited.
+	cfg, err := launcherconfig.Load(filepath.Join(ol.configPath, "actions.d"))
+	if err != nil {
+		return fmt.Errorf("failed to load launcher config: %v", err)
+	}
+	ol.config = cfg
+	ol.actions = applyConfig(actions, cfg)
+
+	return nil
+}
+
+// defaultActions returns the launcher's built-in action set, before any
+// package-manager or actions.d/*.toml layering is applied.
+func defaultActions() []LauncherAction {
+	return []LauncherAction{
+		{
+			ID:          "system-monitor",
+			Name:        "🖥️  System Monitor",
+			Description: "Open the Omarchy system monitoring dashboard",
+			Icon:        "📊",
+			Category:    "system",
+			Command:     "echo",
+			Args:        []string{"Opening system monitor at http://localhost:3000"},
+			Hotkey:      "Super+Shift+S",
+			Priority:    1,
+		},
+		{
+			ID:          "ai-dashboard",
+			Name:        "🤖 AI Dashboard",
+			Description: "Open the AI collaboration dashboard",
+			Icon:        "🧠",
+			Category:    "ai",
+			Command:     "echo",
+			Args:        []string{"AI Team Status and Information"},
+			Hotkey:      "Super+Shift+A",
+			Priority:    1,
+		},
+		{
+			ID:          "planner",
+			Name:        "#pln - Planner",
+			Description: "Launch AI planner subagent",
+			Icon:        "📋",
+			Category:    "ai-subagents",
+			Command:     "tools/ai_subagent.sh",
+			Args:        []string{"pln", "x1"},
+			Hotkey:      "Super+Alt+P",
+			Priority:    2,
+		},
+		{
+			ID:          "implementor",
+			Name:        "#imp - Implementor",
+			Description: "Launch AI implementor subagent",
+			Icon:        "🔨",
+			Category:    "ai-subagents",
+			Command:     "tools/ai_subagent.sh",
+			Args:        []string{"imp", "x0"},
+			Hotkey:      "Super+Alt+I",
+			Priority:    2,
+		},
+		{
+			ID:          "knowledge",
+			Name:        "#knw - Knowledge",
+			Description: "Launch AI knowledge extraction subagent",
+			Icon:        "📚",
+			Category:    "ai-subagents",
+			Command:     "tools/ai_subagent.sh",
+			Args:        []string{"knw", "x0"},
+			Hotkey:      "Super+Alt+K",
+			Priority:    2,
+		},
+		{
+			ID:          "package-manager",
+			Name:        "#pkg - Package Manager",
+			Description: "Launch AI package-manager subagent",
+			Icon:        "📦",
+			Category:    "ai-subagents",
+			Command:     "tools/ai_subagent.sh",
+			Args:        []string{"pkg", "x0"},
+			Hotkey:      "Super+Alt+G",
+			Priority:    2,
+		},
+		{
+			ID:          "continuous-analysis",
+			Name:        "🔄 Start Continuous Analysis",
+			Description: "Start continuous AI analysis",
+			Icon:        "⚡",
+			Category:    "ai",
+			Command:     "node",
+			Args:        []string{"ollama-integration.js", "continuous", "--interval=3"},
+			Hotkey:      "Super+Shift+C",
+			Priority:    3,
+		},
+		{
+			ID:          "lm-studio",
+			Name:        "🧠 LM Studio Integration",
+			Description: "LM Studio setup and integration",
+			Icon:        "🔬",
+			Category:    "ai-tools",
+			Command:     "echo",
+			Args:        []string{"LM Studio integration available via knowledge-outbox"},
+			Hotkey:      "Super+Alt+L",
+			Priority:    4,
+		},
+		{
+			ID:          "go-monitor",
+			Name:        "🐹 Go System Monitor",
+			Description: "Start the Go-based system monitor",
+			Icon:        "⚙️",
+			Category:    "system",
+			Command:     "echo",
+			Args:        []string{"Go system monitor is running at http://localhost:3000"},
+			Hotkey:      "Super+Shift+G",
+			Priority:    2,
+		},
+		{
+			ID:          "terminal",
+			Name:        "💻 Terminal",
+			Description: "Open a new terminal instance",
+			Icon:        "🖥️",
+			Category:    "system",
+			Command:     "alacritty",
+			Args:        []string{},
+			Hotkey:      "Super+Enter",
+			Priority:    1,
+		},
+		{
+			ID:          "config-manager",
+			Name:        "⚙️  Configuration",
+			Description: "Open Omarchy configuration directory",
+			Icon:        "🛠️",
+			Category:    "system",
+			Command:     "echo",
+			Args:        []string{"Configuration directory: ~/.config/omarchy/"},
+			Hotkey:      "Super+Shift+O",
+			Priority:    3,
+		},
+		{
+			ID:          "hyprland-config",
+			Name:        "⌨️  Hyprland Config",
+			Description: "Edit Hyprland configuration",
+			Icon:        "🖱️",
+			Category:    "system",
+			Command:     "nvim",
+			Args:        []string{"/home/zebadiee/.config/hypr/hyprland.conf"},
+			Hotkey:      "Super+Alt+H",
+			Priority:    3,
+		},
+	}
+}
+
+// pkgActions builds the install/remove/search/update LauncherActions for
+// backend, ID-prefixed so e.g. the native pacman actions ("pkg-install")
+// and the flatpak overlay ("pkg-flatpak-install") coexist in the menu.
+func pkgActions(backend pkgmgr.PackageBackend, idPrefix string) []LauncherAction {
+	name := strings.Title(backend.Name())
+	return []LauncherAction{
+		{
+			ID:          idPrefix + "-install",
+			Name:        fmt.Sprintf("📦 Install Package (%s)", name),
+			Description: fmt.Sprintf("Install a package via %s", backend.Name()),
+			Icon:        "📦",
+			Category:    "packages",
+			Priority:    5,
+		},
+		{
+			ID:          idPrefix + "-remove",
+			Name:        fmt.Sprintf("🗑️  Remove Package (%s)", name),
+			Description: fmt.Sprintf("Remove a package via %s", backend.Name()),
+			Icon:        "🗑️",
+			Category:    "packages",
+			Priority:    5,
+		},
+		{
+			ID:          idPrefix + "-search",
+			Name:        fmt.Sprintf("🔍 Search Packages (%s)", name),
+			Description: fmt.Sprintf("Search for a package via %s", backend.Name()),
+			Icon:        "🔍",
+			Category:    "packages",
+			Priority:    5,
+		},
+		{
+			ID:          idPrefix + "-update",
+			Name:        fmt.Sprintf("⬆️  Update Packages (%s)", name),
+			Description: fmt.Sprintf("Update all packages via %s", backend.Name()),
+			Icon:        "⬆️",
+			Category:    "packages",
+			Priority:    5,
+		},
+	}
+}
+
+// applyConfig overlays cfg's per-action overrides onto defaults and drops
+// any action an override marks Disabled, returning a new slice so the
+// built-in defaults list itself stays untouched.
+func applyConfig(defaults []LauncherAction, cfg launcherconfig.Config) []LauncherAction {
+	actions := make([]LauncherAction, 0, len(defaults))
+	for _, action := range defaults {
+		override, ok := cfg.Actions[action.ID]
+		if !ok {
+			actions = append(actions, action)
+			continue
+		}
+		if override.Disabled != nil && *override.Disabled {
+			continue
+		}
+		if override.Command != nil {
+			action.Command = *override.Command
+		}
+		if override.Args != nil {
+			action.Args = override.Args
+		}
+		if override.Hotkey != nil {
+			action.Hotkey = *override.Hotkey
+		}
+		if override.Category != nil {
+			action.Category = *override.Category
+		}
+		if override.Sandbox != nil {
+			action.Sandbox = *override.Sandbox
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// REPL wraps a readline.Instance with persistent history and tab completion
+// over the launcher's actions, so ShowLauncher and RunHotkey can share the
+// same input plumbing instead of each rolling its own bufio reader.
+type REPL struct {
+	instance *readline.Instance
+}
+
+// NewREPL builds a REPL reading from stdin, with history persisted to
+// <configPath>/history and TAB completion delegating to ol.Complete.
+func NewREPL(ol *OmarchyLauncher, prompt string) (*REPL, error) {
+	instance, err := readline.NewEx(&readline.Config{
+		Prompt:          prompt,
+		HistoryFile:     filepath.Join(ol.configPath, "history"),
+		AutoComplete:    &actionCompleter{ol: ol},
+		InterruptPrompt: "^C",
+		EOFPrompt:       "exit",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start readline: %v", err)
+	}
+	return &REPL{instance: instance}, nil
+}
+
+// ReadLine reads one line of input, trimmed of surrounding whitespace.
+func (r *REPL) ReadLine() (string, error) {
+	line, err := r.instance.Readline()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// SetPrompt changes the prompt shown on the next ReadLine.
+func (r *REPL) SetPrompt(prompt string) {
+	r.instance.SetPrompt(prompt)
+}
+
+// Close flushes history to disk and releases the underlying terminal.
+func (r *REPL) Close() error {
+	return r.instance.Close()
+}
+
+// ensureREPL returns the launcher's shared REPL, creating it on first use.
+func (ol *OmarchyLauncher) ensureREPL(prompt string) (*REPL, error) {
+	if ol.repl == nil {
+		repl, err := NewREPL(ol, prompt)
+		if err != nil {
+			return nil, err
+		}
+		ol.repl = repl
+	} else {
+		ol.repl.SetPrompt(prompt)
+	}
+	return ol.repl, nil
+}
+
+// actionCompleter implements readline.AutoCompleter, completing over the
+// launcher's action IDs, names, categories and hotkeys.
+type actionCompleter struct {
+	ol *OmarchyLauncher
+}
+
+func (c *actionCompleter) Do(line []rune, pos int) (newLine [][]rune, length int) {
+	prefix := string(line[:pos])
+	for _, match := range c.ol.Complete(prefix) {
+		if len(match) >= len(prefix) {
+			newLine = append(newLine, []rune(match[len(prefix):]))
+		}
+	}
+	return newLine, len(prefix)
+}
+
+// Complete returns the action IDs, names, categories and hotkeys that
+// contain prefix (case-insensitive), for TAB completion and fuzzy menu
+// filtering alike.
+func (ol *OmarchyLauncher) Complete(prefix string) []string {
+	needle := strings.ToLower(prefix)
+	seen := make(map[string]bool)
+	var matches []string
+	add := func(candidate string) {
+		if candidate == "" || !strings.Contains(strings.ToLower(candidate), needle) {
+			return
+		}
+		if !seen[candidate] {
+			seen[candidate] = true
+			matches = append(matches, candidate)
+		}
+	}
+	for _, action := range ol.actions {
+		add(action.ID)
+		add(action.Name)
+		add(action.Category)
+		add(action.Hotkey)
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// matchActions fuzzy-filters the action list for a non-numeric menu query:
+// an empty query matches everything, otherwise an action matches if its ID
+// or name contains query (case-insensitive), or its icon equals it exactly
+// (so typing the emoji itself narrows the list too).
+func (ol *OmarchyLauncher) matchActions(query string) []LauncherAction {
+	if query == "" {
+		return ol.actions
+	}
+	needle := strings.ToLower(query)
+	var matches []LauncherAction
+	for _, action := range ol.actions {
+		if strings.Contains(strings.ToLower(action.ID), needle) ||
+			strings.Contains(strings.ToLower(action.Name), needle) ||
+			action.Icon == query {
+			matches = append(matches, action)
+		}
+	}
+	return matches
+}
+
+// ShowLauncher displays the launcher interface using terminal input
+func (ol *OmarchyLauncher) ShowLauncher() error {
+	fmt.Println("\n🚂 **OMARCHY DESKTOP LAUNCHER**")
+	fmt.Println("==============================")
+	fmt.Println("🤖 AI-powered desktop integration for Omarchy OS")
+	fmt.Println("")
+
+	// Display categorized actions
+	categories := make(map[string][]LauncherAction)
+	for _, action := range ol.actions {
+		categories[action.Category] = append(categories[action.Category], action)
+	}
+
+	for category, actions := range categories {
+		fmt.Printf("📂 %s\n", strings.Title(category))
+		fmt.Println(strings.Repeat("-", 20))
+		for i, action := range actions {
+			fmt.Printf("%d. %s %s\n", i+1, action.Icon, action.Name)
+		}
+		fmt.Println()
+	}
+
+	// Get user selection: a number picks by menu position, anything else is
+	// fuzzy-matched against action ID/name/icon (e.g. "pln", "plan", "📋").
+	repl, err := ol.ensureREPL("Enter choice (number), name, or 'q' to quit: ")
+	if err != nil {
+		return err
+	}
+	selection, err := repl.ReadLine()
+	if err != nil {
+		if err == readline.ErrInterrupt || err == io.EOF {
+			return nil
+		}
+		return err
+	}
+
+	if selection == "q" || selection == "quit" {
+		return nil
+	}
+
+	if choice, err := strconv.Atoi(selection); err == nil {
+		if choice < 1 || choice > len(ol.actions) {
+			fmt.Println("❌ Invalid selection")
+			return nil
+		}
+		return ol.executeAction(ol.actions[choice-1])
+	}
+
+	matches := ol.matchActions(selection)
+	switch len(matches) {
+	case 0:
+		fmt.Println("❌ No matching action")
+		return nil
+	case 1:
+		return ol.executeAction(matches[0])
+	default:
+		fmt.Printf("Multiple matches for %q:\n", selection)
+		for i, action := range matches {
+			fmt.Printf("%d. %s %s\n", i+1, action.Icon, action.Name)
+		}
+		repl.SetPrompt("Enter choice (number): ")
+		sub, err := repl.ReadLine()
+		if err != nil {
+			if err == readline.ErrInterrupt || err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		choice, err := strconv.Atoi(sub)
+		if err != nil || choice < 1 || choice > len(matches) {
+			fmt.Println("❌ Invalid selection")
+			return nil
+		}
+		return ol.executeAction(matches[choice-1])
+	}
+}
+
+// executeAction runs a launcher action
+func (ol *OmarchyLauncher) executeAction(action LauncherAction) error {
+	fmt.Printf("\n🚂 Executing: %s\n", action.Name)
+	fmt.Printf("📝 Description: %s\n", action.Description)
+
+	if strings.HasPrefix(action.ID, "pkg-") {
+		return ol.executePackageAction(action.ID)
+	}
+
+	switch action.ID {
+	case "system-monitor":
+		// Open system monitor in browser
+		fmt.Println("🌐 Opening system monitor in browser...")
+		go func() {
+			exec.Command("xdg-open", "http://localhost:3000").Run()
+		}()
+		return nil
+	case "ai-dashboard":
+		// Show AI dashboard status
+		return ol.showAIDashboard()
+	case "lm-studio":
+		// Show LM Studio instructions
+		return ol.showLMStudioInstructions()
+	case "go-monitor":
+		// Show Go monitor status
+		return ol.showGoMonitorStatus()
+	case "config-manager":
+		// Show config directory
+		homeDir, _ := os.UserHomeDir()
+		configDir := filepath.Join(homeDir, ".config", "omarchy")
+		fmt.Printf("📁 Configuration directory: %s\n", configDir)
+		return nil
+	default:
+		// Execute command, sandboxed if the action (or an actions.d/*.toml
+		// override) set one up.
+		fmt.Printf("💻 Executing: %s %v\n", action.Command, action.Args)
+		if action.Sandbox.Enable != 0 {
+			handle, err := sandbox.Start(action.Sandbox, action.Command, action.Args)
+			if err != nil {
+				return err
+			}
+			return handle.Wait()
+		}
+		cmd := exec.Command(action.Command, action.Args...)
+		return cmd.Run()
+	}
+}
+
+// executePackageAction prompts for a package name or search query via the
+// shared REPL (matching RunHotkey/ShowLauncher's input plumbing) and runs it
+// against the backend the action's ID prefix selects: the host's native
+// backend for "pkg-*", flatpak for "pkg-flatpak-*".
+func (ol *OmarchyLauncher) executePackageAction(id string) error {
+	backend := ol.pkgBackend
+	rest := strings.TrimPrefix(id, "pkg-")
+	if strings.HasPrefix(id, "pkg-flatpak-") {
+		backend = pkgmgr.FlatpakBackend{}
+		rest = strings.TrimPrefix(id, "pkg-flatpak-")
+	}
+	if backend == nil {
+		return fmt.Errorf("no package backend available for %s", id)
+	}
+
+	if rest == "update" {
+		return backend.Update()
+	}
+
+	var prompt string
+	switch rest {
+	case "install":
+		prompt = "📦 Package name to install: "
+	case "remove":
+		prompt = "🗑️  Package name to remove: "
+	case "search":
+		prompt = "🔍 Search query: "
+	default:
+		return fmt.Errorf("unknown package action: %s", id)
+	}
+
+	repl, err := ol.ensureREPL(prompt)
+	if err != nil {
+		return err
+	}
+	input, err := repl.ReadLine()
+	if err != nil {
+		return err
+	}
+
+	switch rest {
+	case "install":
+		return backend.Install(input)
+	case "remove":
+		return backend.Remove(input)
+	default:
+		return backend.Search(input)
+	}
+}
+
+// findAction looks up an action by ID, for the "run <id>" dispatch path
+// shared by hotkeys, the CLI subcommand and the daemon's Unix socket.
+func (ol *OmarchyLauncher) findAction(id string) (LauncherAction, bool) {
+	for _, action := range ol.actions {
+		if action.ID == id {
+			return action, true
+		}
+	}
+	return LauncherAction{}, false
+}
+
+// runByID is the single executor every hotkey backend, the daemon socket
+// and the "omarchy-launcher run <id>" CLI subcommand all funnel through.
+func (ol *OmarchyLauncher) runByID(id string) error {
+	action, ok := ol.findAction(id)
+	if !ok {
+		return fmt.Errorf("unknown action: %s", id)
+	}
+	return ol.executeAction(action)
+}
+
+// builtinActionIDs are the actions executeAction handles with a direct Go
+// function instead of running action.Command as a subprocess; the D-Bus
+// service can't capture their output the way it can for the default exec
+// path, so ExecuteCaptured skips straight to executeAction for these.
+var builtinActionIDs = map[string]bool{
+	"system-monitor": true,
+	"ai-dashboard":   true,
+	"lm-studio":      true,
+	"go-monitor":     true,
+	"config-manager": true,
+}
+
+// ExecuteCaptured runs action id (optionally overriding its configured
+// Args) the same way runByID does, but captures the subprocess's output
+// and exit code instead of letting it inherit the launcher's own stdio --
+// what the D-Bus service's Execute/ExecuteWithArgs methods need to report
+// back over ActionCompleted.
+func (ol *OmarchyLauncher) ExecuteCaptured(id string, args []string) (exitCode int, stdoutTail string, err error) {
+	action, ok := ol.findAction(id)
+	if !ok {
+		return 1, "", fmt.Errorf("unknown action: %s", id)
+	}
+	if len(args) > 0 {
+		action.Args = args
+	}
+
+	if builtinActionIDs[action.ID] || strings.HasPrefix(action.ID, "pkg-") {
+		if err := ol.executeAction(action); err != nil {
+			return 1, "", err
+		}
+		return 0, "", nil
+	}
+
+	if action.Sandbox.Enable != 0 {
+		// sandbox.Start always inherits the launcher's stdio, so a
+		// sandboxed action's output isn't capturable here yet; report the
+		// exit status with an empty tail rather than silently dropping it.
+		handle, startErr := sandbox.Start(action.Sandbox, action.Command, action.Args)
+		if startErr != nil {
+			return 1, "", startErr
+		}
+		waitErr := handle.Wait()
+		return exitCodeFromErr(waitErr), "", waitErr
+	}
+
+	cmd := exec.Command(action.Command, action.Args...)
+	output, runErr := cmd.CombinedOutput()
+	return exitCodeFromErr(runErr), tailString(string(output), stdoutTailBytes), runErr
+}
+
+// stdoutTailBytes bounds how much captured output ActionCompleted carries,
+// so a noisy command doesn't blow up the signal payload.
+const stdoutTailBytes = 4096
+
+func tailString(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[len(s)-n:]
+}
+
+func exitCodeFromErr(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// launcherDispatcher adapts OmarchyLauncher to dbus.Dispatcher, so the
+// bus service and the CLI's "run <id>" path funnel through the same
+// ExecuteCaptured/executeAction plumbing.
+type launcherDispatcher struct {
+	ol *OmarchyLauncher
+}
+
+func (d launcherDispatcher) ListActions() []launcherdbus.ActionInfo {
+	infos := make([]launcherdbus.ActionInfo, 0, len(d.ol.actions))
+	for _, action := range d.ol.actions {
+		infos = append(infos, launcherdbus.ActionInfo{
+			ID:          action.ID,
+			Name:        action.Name,
+			Description: action.Description,
+			Icon:        action.Icon,
+			Category:    action.Category,
+		})
+	}
+	return infos
+}
+
+func (d launcherDispatcher) Execute(id string, args []string) (int, string, error) {
+	return d.ol.ExecuteCaptured(id, args)
+}
+
+// runDBusService starts the org.omarchy.Launcher1 bus service and blocks
+// until SIGINT/SIGTERM, for the --dbus flag (bus service only, no TUI).
+func runDBusService(ol *OmarchyLauncher) error {
+	service, err := launcherdbus.NewService(launcherDispatcher{ol: ol})
+	if err != nil {
+		return err
+	}
+	defer service.Close()
+
+	fmt.Println("🚂 org.omarchy.Launcher1 registered on the session bus")
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+	<-sig
+	fmt.Println("\n👋 D-Bus service shutting down...")
+	return nil
+}
+
+// socketPath is the Unix socket the daemon listens on for "run <id>"
+// commands, placed in XDG_RUNTIME_DIR alongside other per-session sockets
+// (falling back to /tmp if it isn't set, e.g. under a bare SSH session).
+func socketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "omarchy-launcher.sock")
+}
+
+// runDaemon starts the long-lived hotkey daemon: it registers every
+// action's Hotkey with the detected backend (Hyprland binds, or a raw
+// evdev grab), listens on socketPath for "run <id>" commands, and
+// deregisters cleanly on SIGINT/SIGTERM.
+// bindingsFor collects the hotkey.Binding set for every action that has a
+// Hotkey configured, for (re-)registering with a hotkey.Backend.
+func bindingsFor(actions []LauncherAction) []hotkey.Binding {
+	var bindings []hotkey.Binding
+	for _, action := range actions {
+		if action.Hotkey == "" {
+			continue
+		}
+		bindings = append(bindings, hotkey.Binding{ActionID: action.ID, Hotkey: action.Hotkey})
+	}
+	return bindings
+}
+
+func runDaemon(ol *OmarchyLauncher) error {
+	homeDir, _ := os.UserHomeDir()
+	backend, err := hotkey.DetectBackend(homeDir)
+	if err != nil {
+		return fmt.Errorf("hotkey: detect backend: %w", err)
+	}
+	fmt.Printf("🚂 Hotkey daemon starting (%s backend)\n", backend.Name())
+
+	dispatch := func(actionID string) {
+		if err := ol.runByID(actionID); err != nil {
+			fmt.Printf("❌ %v\n", err)
+		}
+	}
+	if err := backend.Register(bindingsFor(ol.actions), dispatch); err != nil {
+		return fmt.Errorf("hotkey: register binds: %w", err)
+	}
+	defer backend.Deregister()
+
+	path := socketPath()
+	os.Remove(path)
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("hotkey: listen on %s: %w", path, err)
+	}
+	defer os.Remove(path)
+	defer listener.Close()
+
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-shutdown
+		fmt.Println("\n👋 Hotkey daemon shutting down...")
+		listener.Close()
+	}()
+
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			fmt.Println("🔄 SIGHUP received, reloading config and binds...")
+			if err := ol.reloadActions(); err != nil {
+				fmt.Printf("❌ reload failed, keeping previous binds: %v\n", err)
+				continue
+			}
+			if err := backend.Register(bindingsFor(ol.actions), dispatch); err != nil {
+				fmt.Printf("❌ re-registering binds: %v\n", err)
+			}
+		}
+	}()
+
+	fmt.Printf("🔌 Listening on %s\n", path)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return nil
+		}
+		go handleDaemonConn(ol, conn)
+	}
+}
+
+// handleDaemonConn services one "run <id>" request over the daemon socket.
+func handleDaemonConn(ol *OmarchyLauncher, conn net.Conn) {
+	defer conn.Close()
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return
+	}
+	fields := strings.Fields(line)
+	if len(fields) != 2 || fields[0] != "run" {
+		fmt.Fprintf(conn, "error: expected \"run <action-id>\"\n")
+		return
+	}
+	if err := ol.runByID(fields[1]); err != nil {
+		fmt.Fprintf(conn, "error: %v\n", err)
+		return
+	}
+	fmt.Fprintf(conn, "ok\n")
+}
+
+// runCLI implements the "omarchy-launcher run <id>" subcommand: forward
+// the request to a running daemon over its socket, or, if none is
+// listening, execute the action directly in this process.
+func runCLI(ol *OmarchyLauncher, id string) error {
+	conn, err := net.Dial("unix", socketPath())
+	if err != nil {
+		return ol.runByID(id)
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(conn, "run %s\n", id)
+	response, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return err
+	}
+	response = strings.TrimSpace(response)
+	if strings.HasPrefix(response, "error:") {
+		return fmt.Errorf("%s", strings.TrimSpace(strings.TrimPrefix(response, "error:")))
+	}
+	return nil
+}
+
+// showAIDashboard displays the AI team status
+func (ol *OmarchyLauncher) showAIDashboard() error {
+	fmt.Println("\n🤖 **OMARCHY AI DASHBOARD**")
+	fmt.Println("==========================")
+
+	// Read AI team status
+	homeDir, _ := os.UserHomeDir()
+	statusFile := filepath.Join(homeDir, "Documents", "omarchy-ai-assist", "knowledge-outbox", "team-status", "latest.json")
+
+	if _, err := os.Stat(statusFile); err == nil {
+		// Try to read with Node.js (if available)
+		cmd := exec.Command("node", "-e", `
+			try {
+				const fs = require('fs');
+				const data = JSON.parse(fs.readFileSync('`+statusFile+`', 'utf8'));
+				const overview = data.overview || {};
+				console.log('📊 Active Assistants:', overview.activeAssistants + '/' + overview.totalAssistants);
+				console.log('🧠 Knowledge Entries:', overview.knowledgeEntries);
+				console.log('📋 Pending Tasks:', overview.pendingTasks);
+				if (overview.lastCollaboration) {
+					console.log('⏰ Last Collaboration:', overview.lastCollaboration);
+				}
+			} catch(e) {
+				console.log('Error reading status:', e.message);
+			}
+		`)
+
+		if output, err := cmd.CombinedOutput(); err == nil {
+			fmt.Println(string(output))
+		} else {
+			fmt.Println("⚠️  Could not read AI team status (Node.js not available)")
+		}
+	} else {
+		fmt.Println("⚠️  AI team status file not found")
+	}
+
+	fmt.Println("\n🔧 **Available Subagents:**")
+	fmt.Println("#pln - AI planner subagent")
+	fmt.Println("#imp - AI implementor subagent")
+	fmt.Println("#knw - AI knowledge extraction subagent")
+	fmt.Println("#pkg - AI package-manager subagent (install/remove/search/update)")
+
+	fmt.Println("\n🚀 **System Status:**")
+	ol.checkSystemStatus()
+
+	return nil
+}
+
+// showLMStudioInstructions displays LM Studio setup instructions
+func (ol *OmarchyLauncher) showLMStudioInstructions() error {
+	fmt.Println("\n🧠 **LM STUDIO INTEGRATION**")
+	fmt.Println("=============================")
+	fmt.Println("LM Studio provides advanced AI analysis capabilities for your Omarchy system.")
+	fmt.Println("")
+	fmt.Println("📁 **Knowledge Base Location:**")
+	fmt.Println("   ~/Documents/omarchy-ai-assist/knowledge-outbox/")
+	fmt.Println("")
+	fmt.Println("🔄 **Sync Commands:**")
+	fmt.Println("   node lm-studio-integration.js export    # Export to LM Studio")
+	fmt.Println("   node lm-studio-integration.js import    # Import from LM Studio")
+	fmt.Println("   node lm-studio-integration.js sync      # Full bidirectional sync")
+	fmt.Println("")
+	fmt.Println("📊 **Current Status:**")
+	fmt.Println("   ✅ Knowledge bridge established")
+	fmt.Println("   ✅ Export/import functionality ready")
+	fmt.Println("   ✅ AI team insights available")
+	fmt.Println("")
+	fmt.Println("💡 **Usage:**")
+	fmt.Println("   1. Export current AI team knowledge")
+	fmt.Println("   2. Use LM Studio for advanced analysis")
+	fmt.Println("   3. Import insights back to your system")
+
+	return nil
+}
+
+// showGoMonitorStatus displays Go monitor status
+func (ol *OmarchyLauncher) showGoMonitorStatus() error {
+	fmt.Println("\n🐹 **GO SYSTEM MONITOR STATUS**")
+	fmt.Println("===========================")
+
+	// Check if Go monitor is running
+	cmd := exec.Command("pgrep", "-f", "go-system-monitor")
+	if output, err := cmd.CombinedOutput(); err == nil {
+		pids := strings.Fields(strings.TrimSpace(string(output)))
+		if len(pids) > 0 {
+			fmt.Printf("✅ Go System Monitor is running (PID: %s)\n", pids[0])
+			fmt.Println("🌐 Web interface: http://localhost:3000")
+
+			// Try to open in browser
+			go func() {
+				exec.Command("xdg-open", "http://localhost:3000").Run()
+			}()
+		} else {
+			fmt.Println("✅ Go System Monitor is running")
+			fmt.Println("🌐 Web interface: http://localhost:3000")
+		}
+	} else {
+		fmt.Println("❌ Go System Monitor is not running")
+		fmt.Println("💡 Start with: /usr/local/go/bin/go run go-system-monitor.go")
+	}
+
+	return nil
+}
+
+// checkSystemStatus checks the status of system components
+func (ol *OmarchyLauncher) checkSystemStatus() {
+	components := []struct {
+		name   string
+		cmd    string
+		args   []string
+		status string
+		icon   string
+	}{
+		{"Ollama", "ollama", []string{"list"}, "✅", "🧠"},
+		{"Node.js", "node", []string{"--version"}, "✅", "🟢"},
+		{"Go", "/usr/local/go/bin/go", []string{"version"}, "✅", "🐹"},
+		{"Alacritty", "alacritty", []string{"--version"}, "✅", "🖥️"},
+		{"Hyprland", "hyprctl", []string{"version"}, "✅", "🪟"},
+	}
+
+	fmt.Printf("\n🔧 System Components Status:\n")
+	for _, comp := range components {
+		cmd := exec.Command(comp.cmd, comp.args...)
+		if err := cmd.Run(); err == nil {
+			fmt.Printf("   %s %s %s\n", comp.icon, comp.status, comp.name)
+		} else {
+			fmt.Printf("   ❌ %s (Missing)\n", comp.name)
+		}
+	}
+}
+
+// RunHotkey starts the launcher with hotkey support
+func (ol *OmarchyLauncher) RunHotkey() error {
+	fmt.Println("🚂 **OMARCHY LAUNCHER HOTKEY MODE**")
+	fmt.Println("==================================")
+	fmt.Println("Press Space to show launcher, q to quit")
+	fmt.Println("")
+
+	// Simple key monitoring loop, sharing ShowLauncher's REPL (and thus its
+	// history and completion) rather than reading stdin separately.
+	repl, err := ol.ensureREPL("Press 'space' for launcher, 'q' to quit: ")
+	if err != nil {
+		return err
+	}
+	for {
+		repl.SetPrompt("Press 'space' for launcher, 'q' to quit: ")
+		input, err := repl.ReadLine()
+		if err != nil {
+			if err == readline.ErrInterrupt || err == io.EOF {
+				fmt.Println("👋 Exiting launcher...")
+				return nil
+			}
+			return err
+		}
+		input = strings.ToLower(input)
+
+		switch input {
+		case "q", "quit", "exit":
+			fmt.Println("👋 Exiting launcher...")
+			return nil
+		case " ", "space", "launcher":
+			if err := ol.ShowLauncher(); err != nil {
+				fmt.Printf("❌ Launcher error: %v\n", err)
+			}
+		default:
+			fmt.Println("❌ Invalid input. Use 'space' or 'q'.")
+		}
+	}
+}
+
+func main() {
+	launcher := NewOmarchyLauncher()
+
+	// Initialize launcher
+	if err := launcher.Initialize(); err != nil {
+		log.Fatalf("❌ Failed to initialize launcher: %v", err)
+	}
+	defer func() {
+		if launcher.repl != nil {
+			launcher.repl.Close()
+		}
+	}()
+
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "--print-config":
+		if err := launcher.config.Dump(os.Stdout); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	case len(os.Args) > 1 && os.Args[1] == "--dbus":
+		if err := runDBusService(launcher); err != nil {
+			log.Fatalf("❌ D-Bus service error: %v", err)
+		}
+		return
+	case len(os.Args) > 1 && os.Args[1] == "--daemon":
+		if err := runDaemon(launcher); err != nil {
+			log.Fatalf("❌ Hotkey daemon error: %v", err)
+		}
+		return
+	case len(os.Args) > 2 && os.Args[1] == "run":
+		if err := runCLI(launcher, os.Args[2]); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	case len(os.Args) > 1 && os.Args[1] == "--hotkey":
+		if err := launcher.RunHotkey(); err != nil {
+			log.Printf("❌ Hotkey mode error: %v", err)
+		}
+	default:
+		// Show launcher once
+		if err := launcher.ShowLauncher(); err != nil {
+			log.Printf("❌ Launcher error: %v", err)
+		}
+	}
+
+	fmt.Println("\n✨ Have a productive day with Omarchy! 🌟")
+}
@@ -0,0 +1,1368 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/actionsapi"
+	"github.com/zebadiee/omarchy-ai-assist/internal/hyprland"
+	"github.com/zebadiee/omarchy-ai-assist/internal/sandbox"
+)
+
+// OmarchyLauncher represents the main desktop launcher system
+type OmarchyLauncher struct {
+	configPath string
+	frontend   LauncherFrontend
+	actions    []LauncherAction
+	lastUpdate time.Time
+
+	// FocusAwareActions marks action IDs that should run relative to the
+	// currently focused window instead of the launcher's own cwd, e.g.
+	// "file-manager" opening in the directory of the focused terminal.
+	FocusAwareActions map[string]bool
+
+	hypr               *hyprland.Client
+	lastWorkspace      string
+	focusedWindowClass string
+	focusedWindowDir   string
+	activeMonitor      string
+
+	// frecency ranks actions by recent usage for ShowLauncher's "Recent"
+	// section; see loadFrecency and (*OmarchyLauncher).rankedActions.
+	frecency *FrecencyStore
+}
+
+// LauncherAction represents an action available in the launcher
+type LauncherAction struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Icon        string                 `json:"icon"`
+	Category    string                 `json:"category"`
+	Command     string                 `json:"command"`
+	Args        []string               `json:"args"`
+	Hotkey      string                 `json:"hotkey"`
+	Priority    int                    `json:"priority"`
+	Sandbox     sandbox.SandboxProfile `json:"sandbox,omitempty"`
+	// ContextHints are matched (case-insensitively, by substring) against
+	// the focused window's class and the active workspace name; a match
+	// adds frecencyContextBonus to the action's ranking score.
+	ContextHints []string `json:"context_hints,omitempty"`
+}
+
+// LauncherConfig holds the launcher configuration
+type LauncherConfig struct {
+	Hotkey        string            `json:"hotkey"`
+	ShowOnStartup bool              `json:"show_on_startup"`
+	Actions       []LauncherAction  `json:"actions"`
+	CustomActions map[string]Action `json:"custom_actions"`
+	// Frontend overrides auto-detection ("wofi", "rofi", "fuzzel",
+	// "dmenu", or "tui"). Empty means pick the first available binary,
+	// falling back to the TUI for a plain TTY with none installed.
+	Frontend string `json:"frontend,omitempty"`
+}
+
+// Action represents a custom action configuration
+type Action struct {
+	Name        string                 `json:"name"`
+	Command     string                 `json:"command"`
+	Args        []string               `json:"args"`
+	Description string                 `json:"description"`
+	Sandbox     sandbox.SandboxProfile `json:"sandbox,omitempty"`
+}
+
+// NewOmarchyLauncher creates a new launcher instance
+func NewOmarchyLauncher() *OmarchyLauncher {
+	homeDir, _ := os.UserHomeDir()
+	configPath := filepath.Join(homeDir, ".config", "omarchy", "launcher")
+
+	return &OmarchyLauncher{
+		configPath: configPath,
+		frontend:   DetectFrontend(""),
+		lastUpdate: time.Now(),
+	}
+}
+
+// Initialize sets up the launcher with default actions
+func (ol *OmarchyLauncher) Initialize() error {
+	// Ensure config directory exists
+	if err := os.MkdirAll(ol.configPath, 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %v", err)
+	}
+
+	frecency, err := loadFrecency(filepath.Join(ol.configPath, "frecency.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load frecency store: %v", err)
+	}
+	ol.frecency = frecency
+
+	// The AI subagents below read and write the project they're invoked
+	// from (tools/ai_subagent.sh expects to run from the repo root), so
+	// their sandbox needs that directory bound in rather than just the
+	// read-only /usr and /etc every profile gets by default.
+	projectDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to resolve project directory: %v", err)
+	}
+	subagentSandbox := sandbox.SandboxProfile{
+		Enable:     sandbox.Filesystem | sandbox.Net,
+		ExtraBinds: []string{projectDir},
+	}
+
+	// Initialize default actions
+	ol.actions = []LauncherAction{
+		{
+			ID:          "system-monitor",
+			Name:        "🖥️  System Monitor",
+			Description: "Open the Omarchy system monitoring dashboard",
+			Icon:        "📊",
+			Category:    "system",
+			Command:     "curl",
+			Args:        []string{"-s", "http://localhost:3000/api/metrics"},
+			Hotkey:      "Super+Shift+S",
+			Priority:    1,
+		},
+		{
+			ID:           "ai-dashboard",
+			Name:         "🤖 AI Dashboard",
+			Description:  "Open the AI collaboration dashboard",
+			Icon:         "🧠",
+			Category:     "ai",
+			Command:      "echo",
+			Args:         []string{"Opening AI dashboard..."},
+			Hotkey:       "Super+Shift+A",
+			Priority:     1,
+			ContextHints: []string{"claude"},
+		},
+		{
+			ID:          "planner",
+			Name:        "#pln - Planner",
+			Description: "Launch AI planner subagent",
+			Icon:        "📋",
+			Category:    "ai-subagents",
+			Command:     "tools/ai_subagent.sh",
+			Args:        []string{"pln", "x1"},
+			Hotkey:      "Super+Alt+P",
+			Priority:    2,
+			Sandbox:     subagentSandbox,
+		},
+		{
+			ID:          "implementor",
+			Name:        "#imp - Implementor",
+			Description: "Launch AI implementor subagent",
+			Icon:        "🔨",
+			Category:    "ai-subagents",
+			Command:     "tools/ai_subagent.sh",
+			Args:        []string{"imp", "x0"},
+			Hotkey:      "Super+Alt+I",
+			Priority:    2,
+			Sandbox:     subagentSandbox,
+		},
+		{
+			ID:          "knowledge",
+			Name:        "#knw - Knowledge",
+			Description: "Launch AI knowledge extraction subagent",
+			Icon:        "📚",
+			Category:    "ai-subagents",
+			Command:     "tools/ai_subagent.sh",
+			Args:        []string{"knw", "x0"},
+			Hotkey:      "Super+Alt+K",
+			Priority:    2,
+			Sandbox:     subagentSandbox,
+		},
+		{
+			ID:          "continuous-analysis",
+			Name:        "🔄 Start Continuous Analysis",
+			Description: "Start continuous AI analysis",
+			Icon:        "⚡",
+			Category:    "ai",
+			Command:     "node",
+			Args:        []string{"ollama-integration.js", "continuous", "--interval=3"},
+			Hotkey:      "Super+Shift+C",
+			Priority:    3,
+		},
+		{
+			ID:          "lm-studio",
+			Name:        "🧠 LM Studio",
+			Description: "Launch LM Studio for advanced AI analysis",
+			Icon:        "🔬",
+			Category:    "ai-tools",
+			Command:     "echo",
+			Args:        []string{"Launch LM Studio manually or via AppImage"},
+			Hotkey:      "Super+Alt+L",
+			Priority:    4,
+		},
+		{
+			ID:          "go-monitor",
+			Name:        "🐹 Go System Monitor",
+			Description: "Start the Go-based system monitor",
+			Icon:        "⚙️",
+			Category:    "system",
+			Command:     "/usr/local/go/bin/go",
+			Args:        []string{"run", "go-system-monitor.go"},
+			Hotkey:      "Super+Shift+G",
+			Priority:    2,
+		},
+		{
+			ID:          "terminal",
+			Name:        "💻 Terminal",
+			Description: "Open a new terminal instance",
+			Icon:        "🖥️",
+			Category:    "system",
+			Command:     "alacritty",
+			Args:        []string{},
+			Hotkey:      "Super+Enter",
+			Priority:    1,
+			Sandbox:     sandbox.SandboxProfile{Enable: sandbox.Wayland | sandbox.X11 | sandbox.Net},
+		},
+		{
+			ID:          "file-manager",
+			Name:        "📁 File Manager",
+			Description: "Open file manager in current directory",
+			Icon:        "📂",
+			Category:    "system",
+			Command:     "thunar",
+			Args:        []string{"."},
+			Hotkey:      "Super+E",
+			Priority:    1,
+			Sandbox:     sandbox.SandboxProfile{Enable: sandbox.Wayland | sandbox.X11},
+		},
+		{
+			ID:          "config-manager",
+			Name:        "⚙️  Configuration",
+			Description: "Open Omarchy configuration directory",
+			Icon:        "🛠️",
+			Category:    "system",
+			Command:     "thunar",
+			Args:        []string{"/home/zebadiee/.config/omarchy"},
+			Hotkey:      "Super+Shift+O",
+			Priority:    3,
+		},
+		{
+			ID:          "waybar-reload",
+			Name:        "🔄 Reload Waybar",
+			Description: "Reload the Waybar status bar",
+			Icon:        "📊",
+			Category:    "system",
+			Command:     "pkill",
+			Args:        []string{"-USR1", "waybar"},
+			Hotkey:      "Super+Shift+W",
+			Priority:    4,
+		},
+		{
+			ID:          "hyprland-config",
+			Name:        "⌨️  Hyprland Config",
+			Description: "Open Hyprland configuration",
+			Icon:        "🖱️",
+			Category:    "system",
+			Command:     "nvim",
+			Args:        []string{"/home/zebadiee/.config/hypr/hyprland.conf"},
+			Hotkey:      "Super+Alt+H",
+			Priority:    3,
+		},
+	}
+
+	ol.FocusAwareActions = map[string]bool{"file-manager": true}
+
+	return nil
+}
+
+// frecencyRecentN bounds the "Recent" section rankedActions promotes to the
+// top of the list; frecencyContextBonus is added to an action's score when
+// its ContextHints match the currently focused window/workspace.
+const (
+	frecencyRecentN      = 5
+	frecencyContextBonus = 50
+)
+
+// frecencyAgeBuckets assigns a Mozilla-style recency weight to how long ago
+// a visit happened, checked in order so the first bucket a visit's age
+// falls under wins.
+var frecencyAgeBuckets = []struct {
+	maxAge time.Duration
+	weight float64
+}{
+	{4 * 24 * time.Hour, 100},
+	{14 * 24 * time.Hour, 70},
+	{31 * 24 * time.Hour, 50},
+	{90 * 24 * time.Hour, 30},
+}
+
+// frecencyDefaultWeight is the weight for visits older than every bucket
+// above.
+const frecencyDefaultWeight = 10
+
+func frecencyAgeWeight(age time.Duration) float64 {
+	for _, bucket := range frecencyAgeBuckets {
+		if age < bucket.maxAge {
+			return bucket.weight
+		}
+	}
+	return frecencyDefaultWeight
+}
+
+// frecencyVisitTypeWeight distinguishes a deliberate launcher selection
+// from a hotkey-triggered invocation, since picking something out of a
+// list says more about preference than a bound hotkey firing does.
+func frecencyVisitTypeWeight(visitType string) float64 {
+	if visitType == "hotkey" {
+		return 0.5
+	}
+	return 1.0
+}
+
+// FrecencyVisit is one recorded invocation of an action.
+type FrecencyVisit struct {
+	Time time.Time `json:"time"`
+	Type string    `json:"type"` // "launcher" or "hotkey"
+}
+
+// FrecencyStore tracks recent invocations per action ID, persisted to
+// frecency.json so ranking survives restarts.
+type FrecencyStore struct {
+	path   string
+	Visits map[string][]FrecencyVisit `json:"visits"`
+}
+
+// loadFrecency reads path's frecency.json, returning an empty store if it
+// doesn't exist yet -- the same "missing file isn't an error" convention
+// launcherconfig.Load uses for actions.d/*.toml.
+func loadFrecency(path string) (*FrecencyStore, error) {
+	store := &FrecencyStore{path: path, Visits: make(map[string][]FrecencyVisit)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("frecency: read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("frecency: parse %s: %w", path, err)
+	}
+	return store, nil
+}
+
+// Record appends a visit of visitType ("launcher" or "hotkey") for id and
+// persists the store immediately, so a crash doesn't lose usage history.
+func (fs *FrecencyStore) Record(id, visitType string) error {
+	fs.Visits[id] = append(fs.Visits[id], FrecencyVisit{Time: time.Now(), Type: visitType})
+	return fs.save()
+}
+
+// Reset clears every recorded visit, for --reset-ranking.
+func (fs *FrecencyStore) Reset() error {
+	fs.Visits = make(map[string][]FrecencyVisit)
+	return fs.save()
+}
+
+func (fs *FrecencyStore) save() error {
+	data, err := json.MarshalIndent(fs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("frecency: marshal: %w", err)
+	}
+	return os.WriteFile(fs.path, data, 0644)
+}
+
+// Score sums weight(age_bucket) * type_weight across every visit recorded
+// for id, as of now.
+func (fs *FrecencyStore) Score(id string, now time.Time) float64 {
+	var total float64
+	for _, visit := range fs.Visits[id] {
+		total += frecencyAgeWeight(now.Sub(visit.Time)) * frecencyVisitTypeWeight(visit.Type)
+	}
+	return total
+}
+
+// matchesContext reports whether any of action's ContextHints
+// case-insensitively matches the focused window's class or the active
+// workspace, e.g. "claude" matching a terminal titled/classed for it.
+func (ol *OmarchyLauncher) matchesContext(action LauncherAction) bool {
+	class := strings.ToLower(ol.focusedWindowClass)
+	workspace := strings.ToLower(ol.lastWorkspace)
+	for _, hint := range action.ContextHints {
+		h := strings.ToLower(hint)
+		if h == "" {
+			continue
+		}
+		if strings.Contains(class, h) || strings.Contains(workspace, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// rankedActions scores every action by frecency.Score plus any contextual
+// boost, promotes the top frecencyRecentN non-zero scorers to a "Recent"
+// section at the front (each relabeled with a 🕘 marker), and leaves the
+// remaining actions in their original declaration order. It's only used
+// for the interactive picker -- --exec/--action and hotkey dispatch always
+// run the exact action ID they were given.
+func (ol *OmarchyLauncher) rankedActions() []LauncherAction {
+	now := time.Now()
+	scores := make(map[string]float64, len(ol.actions))
+	for _, action := range ol.actions {
+		score := ol.frecency.Score(action.ID, now)
+		if ol.matchesContext(action) {
+			score += frecencyContextBonus
+		}
+		scores[action.ID] = score
+	}
+
+	byScore := append([]LauncherAction(nil), ol.actions...)
+	sortActionsByScore(byScore, scores)
+
+	recent := make([]LauncherAction, 0, frecencyRecentN)
+	inRecent := make(map[string]bool, frecencyRecentN)
+	for _, action := range byScore {
+		if len(recent) >= frecencyRecentN || scores[action.ID] <= 0 {
+			break
+		}
+		recentAction := action
+		recentAction.Name = "🕘 " + action.Name
+		recent = append(recent, recentAction)
+		inRecent[action.ID] = true
+	}
+
+	ranked := recent
+	for _, action := range ol.actions {
+		if !inRecent[action.ID] {
+			ranked = append(ranked, action)
+		}
+	}
+	return ranked
+}
+
+// sortActionsByScore stable-sorts actions by scores descending. An
+// insertion sort is enough here: the action list is at most a few dozen
+// entries, so it isn't worth sort.Slice's reflection overhead for.
+func sortActionsByScore(actions []LauncherAction, scores map[string]float64) {
+	for i := 1; i < len(actions); i++ {
+		for j := i; j > 0 && scores[actions[j].ID] > scores[actions[j-1].ID]; j-- {
+			actions[j], actions[j-1] = actions[j-1], actions[j]
+		}
+	}
+}
+
+// LauncherFrontend presents a picker over actions and reports back the one
+// chosen by its position in the slice, never by matching label text, so
+// duplicated or emoji-stripped names can't resolve to the wrong action.
+type LauncherFrontend interface {
+	Name() string
+	Present(actions []LauncherAction) (action LauncherAction, ok bool, err error)
+}
+
+// menuLabel is the text shown for action across every dmenu-style frontend.
+func menuLabel(action LauncherAction) string {
+	return fmt.Sprintf("%s %s", action.Icon, action.Name)
+}
+
+// DetectFrontend returns the frontend named by override, or the first of
+// wofi/rofi/fuzzel/dmenu found on PATH, falling back to the TUI if none of
+// them are installed (e.g. a plain TTY with no Wayland/X11 session).
+func DetectFrontend(override string) LauncherFrontend {
+	switch override {
+	case "wofi":
+		return wofiFrontend{}
+	case "rofi":
+		return rofiFrontend{}
+	case "fuzzel":
+		return fuzzelFrontend{}
+	case "dmenu":
+		return dmenuFrontend{}
+	case "tui":
+		return tuiFrontend{}
+	}
+
+	candidates := []LauncherFrontend{wofiFrontend{}, rofiFrontend{}, fuzzelFrontend{}, dmenuFrontend{}}
+	for _, f := range candidates {
+		if _, err := exec.LookPath(f.Name()); err == nil {
+			return f
+		}
+	}
+	return tuiFrontend{}
+}
+
+// dmenuStyleSelect feeds one label per line to cmd's stdin and returns
+// whatever it writes to stdout, trimmed. Shared by every frontend that
+// speaks the classic dmenu protocol (wofi, rofi, fuzzel, dmenu itself).
+func dmenuStyleSelect(cmd *exec.Cmd, labels []string) (string, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return "", fmt.Errorf("create stdin pipe: %w", err)
+	}
+	go func() {
+		defer stdin.Close()
+		for _, label := range labels {
+			fmt.Fprintln(stdin, label)
+		}
+	}()
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// wofiFrontend drives wofi in dmenu mode. Wofi only ever echoes back the
+// label text it was given, so the selection is resolved by exact-matching
+// that text against the labels list built for this call.
+type wofiFrontend struct{}
+
+func (wofiFrontend) Name() string { return "wofi" }
+
+func (wofiFrontend) Present(actions []LauncherAction) (LauncherAction, bool, error) {
+	labels := make([]string, len(actions))
+	for i, action := range actions {
+		labels[i] = menuLabel(action)
+	}
+
+	cmd := exec.Command("wofi",
+		"--dmenu",
+		"--prompt=🚂 Omarchy Launcher",
+		"--insensitive",
+		"--allow-markup",
+		"--allow-images",
+		"--matching=fuzzy",
+		"--location=center",
+		"--width=600",
+		"--height=400",
+	)
+	selection, err := dmenuStyleSelect(cmd, labels)
+	if err != nil {
+		return LauncherAction{}, false, fmt.Errorf("wofi: %w", err)
+	}
+	if selection == "" {
+		return LauncherAction{}, false, nil
+	}
+	for i, label := range labels {
+		if label == selection {
+			return actions[i], true, nil
+		}
+	}
+	return LauncherAction{}, false, fmt.Errorf("no matching action found for selection: %s", selection)
+}
+
+// dmenuFrontend drives plain dmenu the same way wofiFrontend drives wofi:
+// dmenu also only ever echoes back label text.
+type dmenuFrontend struct{}
+
+func (dmenuFrontend) Name() string { return "dmenu" }
+
+func (dmenuFrontend) Present(actions []LauncherAction) (LauncherAction, bool, error) {
+	labels := make([]string, len(actions))
+	for i, action := range actions {
+		labels[i] = menuLabel(action)
+	}
+
+	cmd := exec.Command("dmenu", "-i", "-p", "Omarchy Launcher")
+	selection, err := dmenuStyleSelect(cmd, labels)
+	if err != nil {
+		return LauncherAction{}, false, fmt.Errorf("dmenu: %w", err)
+	}
+	if selection == "" {
+		return LauncherAction{}, false, nil
+	}
+	for i, label := range labels {
+		if label == selection {
+			return actions[i], true, nil
+		}
+	}
+	return LauncherAction{}, false, fmt.Errorf("no matching action found for selection: %s", selection)
+}
+
+// rofiFrontend drives rofi with -format i, which prints the 0-based index
+// of the selected entry instead of its text -- so the selection resolves
+// correctly even if two actions share an identical label.
+type rofiFrontend struct{}
+
+func (rofiFrontend) Name() string { return "rofi" }
+
+func (rofiFrontend) Present(actions []LauncherAction) (LauncherAction, bool, error) {
+	labels := make([]string, len(actions))
+	for i, action := range actions {
+		labels[i] = menuLabel(action)
+	}
+
+	cmd := exec.Command("rofi", "-dmenu", "-format", "i", "-p", "🚂 Omarchy Launcher")
+	selection, err := dmenuStyleSelect(cmd, labels)
+	if err != nil {
+		return LauncherAction{}, false, fmt.Errorf("rofi: %w", err)
+	}
+	return actionByIndex(actions, selection)
+}
+
+// fuzzelFrontend drives fuzzel with --dmenu --index, which like rofi's
+// -format i prints the selected entry's index rather than its text.
+type fuzzelFrontend struct{}
+
+func (fuzzelFrontend) Name() string { return "fuzzel" }
+
+func (fuzzelFrontend) Present(actions []LauncherAction) (LauncherAction, bool, error) {
+	labels := make([]string, len(actions))
+	for i, action := range actions {
+		labels[i] = menuLabel(action)
+	}
+
+	cmd := exec.Command("fuzzel", "--dmenu", "--index", "--prompt=🚂 Omarchy Launcher ")
+	selection, err := dmenuStyleSelect(cmd, labels)
+	if err != nil {
+		return LauncherAction{}, false, fmt.Errorf("fuzzel: %w", err)
+	}
+	return actionByIndex(actions, selection)
+}
+
+// actionByIndex parses selection as a 0-based index into actions, for the
+// rofi/fuzzel frontends that report selections that way.
+func actionByIndex(actions []LauncherAction, selection string) (LauncherAction, bool, error) {
+	if selection == "" {
+		return LauncherAction{}, false, nil
+	}
+	idx, err := strconv.Atoi(selection)
+	if err != nil || idx < 0 || idx >= len(actions) {
+		return LauncherAction{}, false, fmt.Errorf("unexpected selection index %q", selection)
+	}
+	return actions[idx], true, nil
+}
+
+// tuiFrontend is the Bubble Tea fallback for a plain TTY with none of
+// wofi/rofi/fuzzel/dmenu installed -- e.g. over SSH, or a non-Hyprland/
+// non-Sway session.
+type tuiFrontend struct{}
+
+func (tuiFrontend) Name() string { return "tui" }
+
+func (tuiFrontend) Present(actions []LauncherAction) (LauncherAction, bool, error) {
+	model := newLauncherTUIModel(actions)
+	program := tea.NewProgram(model)
+	final, err := program.Run()
+	if err != nil {
+		return LauncherAction{}, false, fmt.Errorf("tui: %w", err)
+	}
+	result := final.(launcherTUIModel)
+	if !result.chose {
+		return LauncherAction{}, false, nil
+	}
+	return actions[result.cursor], true, nil
+}
+
+// launcherTUIModel is a minimal up/down/enter list picker over menuLabel'd
+// actions, read line-by-line rather than raw terminal mode so it degrades
+// gracefully to piped/non-TTY input instead of hanging.
+type launcherTUIModel struct {
+	actions []LauncherAction
+	cursor  int
+	chose   bool
+	done    bool
+}
+
+func newLauncherTUIModel(actions []LauncherAction) launcherTUIModel {
+	return launcherTUIModel{actions: actions}
+}
+
+func (m launcherTUIModel) Init() tea.Cmd { return nil }
+
+func (m launcherTUIModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	switch keyMsg.String() {
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.actions)-1 {
+			m.cursor++
+		}
+	case "enter":
+		m.chose = true
+		m.done = true
+		return m, tea.Quit
+	case "esc", "ctrl+c", "q":
+		m.done = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m launcherTUIModel) View() string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "🚂 Omarchy Launcher (↑/↓ to move, enter to run, esc to cancel)")
+	for i, action := range m.actions {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "› "
+		}
+		fmt.Fprintf(&b, "%s%s\n", cursor, menuLabel(action))
+	}
+	return b.String()
+}
+
+// refreshFocusSnapshot queries Hyprland once for the currently focused
+// window's class and active workspace, so matchesContext has something
+// to compare ContextHints against on an ordinary one-shot interactive
+// invocation -- not just the long-running --hotkey process, which keeps
+// the same fields current via its own event subscription.
+func (ol *OmarchyLauncher) refreshFocusSnapshot() {
+	if class, ok := activeWindowClass(); ok {
+		ol.focusedWindowClass = class
+	}
+	if workspace, ok := activeWorkspaceName(); ok {
+		ol.lastWorkspace = workspace
+	}
+}
+
+func activeWindowClass() (string, bool) {
+	output, err := exec.Command("hyprctl", "activewindow", "-j").Output()
+	if err != nil {
+		return "", false
+	}
+	var win struct {
+		Class string `json:"class"`
+	}
+	if err := json.Unmarshal(output, &win); err != nil || win.Class == "" {
+		return "", false
+	}
+	return win.Class, true
+}
+
+func activeWorkspaceName() (string, bool) {
+	output, err := exec.Command("hyprctl", "activeworkspace", "-j").Output()
+	if err != nil {
+		return "", false
+	}
+	var ws struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(output, &ws); err != nil || ws.Name == "" {
+		return "", false
+	}
+	return ws.Name, true
+}
+
+// ShowLauncher presents ol.actions through whichever LauncherFrontend was
+// detected (or configured) and executes whatever the user picks.
+func (ol *OmarchyLauncher) ShowLauncher() error {
+	ol.refreshFocusSnapshot()
+	action, ok, err := ol.frontend.Present(ol.rankedActions())
+	if err != nil {
+		return fmt.Errorf("%s frontend failed: %w", ol.frontend.Name(), err)
+	}
+	if !ok {
+		return nil // User cancelled
+	}
+	return ol.executeAction(action, "launcher")
+}
+
+// executeAction runs a launcher action, recording it as a visitType
+// ("launcher" or "hotkey") visit for rankedActions' frecency scoring.
+func (ol *OmarchyLauncher) executeAction(action LauncherAction, visitType string) error {
+	log.Printf("🚂 Executing action: %s", action.Name)
+	if err := ol.frecency.Record(action.ID, visitType); err != nil {
+		log.Printf("⚠️  Warning: failed to record usage for %s: %v", action.ID, err)
+	}
+
+	switch action.ID {
+	case "system-monitor":
+		// Open system monitor in browser
+		return exec.Command("xdg-open", "http://localhost:3000").Run()
+	case "ai-dashboard":
+		// Show AI dashboard status
+		return ol.showAIDashboard()
+	case "lm-studio":
+		// Show LM Studio instructions
+		return ol.showLMStudioInstructions()
+	case "go-monitor":
+		// Start Go system monitor if not running
+		return ol.startGoMonitor()
+	default:
+		if ol.FocusAwareActions[action.ID] && ol.focusedWindowDir != "" {
+			action = ol.withFocusedDir(action)
+		}
+		// Execute command under action.Sandbox's bwrap/xdg-dbus-proxy
+		// profile instead of inheriting this process's full session access.
+		handle, err := sandbox.Start(action.Sandbox, action.Command, action.Args)
+		if err != nil {
+			return fmt.Errorf("failed to start sandboxed action: %w", err)
+		}
+		return handle.Wait()
+	}
+}
+
+// builtinActionIDs are the actions executeAction handles with a direct Go
+// function instead of running action.Command as a subprocess;
+// ExecuteCaptured can't capture their output the way it can the default
+// sandboxed exec path, so it runs them through executeAction directly.
+var builtinActionIDs = map[string]bool{
+	"system-monitor": true,
+	"ai-dashboard":   true,
+	"lm-studio":      true,
+	"go-monitor":     true,
+}
+
+// ExecuteCaptured runs action id (optionally overriding its configured
+// Args) the same way executeAction does, but captures the sandboxed
+// child's stdout/stderr and exit code instead of inheriting the
+// launcher's own -- what actionsapi's POST /actions/{id}/invoke needs to
+// report back. It still records the visit for frecency scoring, as
+// "launcher" -- an HTTP caller picking an action is closer to a
+// deliberate picker choice than a bound hotkey firing.
+func (ol *OmarchyLauncher) ExecuteCaptured(id string, args []string) (exitCode int, stdout, stderr string, err error) {
+	action, ok := ol.findAction(id)
+	if !ok {
+		return 1, "", "", fmt.Errorf("unknown action: %s", id)
+	}
+	if len(args) > 0 {
+		action.Args = args
+	}
+
+	if builtinActionIDs[action.ID] {
+		// executeAction records the visit itself; recording it again here
+		// too would double-weight these actions' frecency score.
+		if err := ol.executeAction(action, "launcher"); err != nil {
+			return 1, "", "", err
+		}
+		return 0, "", "", nil
+	}
+
+	if err := ol.frecency.Record(action.ID, "launcher"); err != nil {
+		log.Printf("⚠️  Warning: failed to record usage for %s: %v", action.ID, err)
+	}
+
+	if ol.FocusAwareActions[action.ID] && ol.focusedWindowDir != "" {
+		action = ol.withFocusedDir(action)
+	}
+	return sandbox.StartCaptured(action.Sandbox, action.Command, action.Args)
+}
+
+// actionsDispatcher adapts OmarchyLauncher to actionsapi.Dispatcher, so
+// the HTTP API and the CLI's --exec path funnel through the same
+// ExecuteCaptured/executeAction plumbing.
+type actionsDispatcher struct {
+	ol *OmarchyLauncher
+}
+
+func (d actionsDispatcher) ListActions() []actionsapi.ActionInfo {
+	infos := make([]actionsapi.ActionInfo, 0, len(d.ol.actions))
+	for _, action := range d.ol.actions {
+		infos = append(infos, actionsapi.ActionInfo{
+			ID:          action.ID,
+			Name:        action.Name,
+			Description: action.Description,
+			Icon:        action.Icon,
+			Category:    action.Category,
+		})
+	}
+	return infos
+}
+
+func (d actionsDispatcher) Execute(id string, args []string) (int, string, string, error) {
+	return d.ol.ExecuteCaptured(id, args)
+}
+
+// runActionsAPI starts the action-dispatch HTTP API and blocks until
+// SIGINT/SIGTERM, for the --api flag. It binds to loopback only and
+// requires the bearer token at <configPath>/token, generating one on
+// first run.
+func runActionsAPI(ol *OmarchyLauncher) error {
+	token, err := actionsapi.EnsureToken(filepath.Join(ol.configPath, "token"))
+	if err != nil {
+		return fmt.Errorf("actions API: %w", err)
+	}
+
+	server := actionsapi.NewServer(actionsDispatcher{ol: ol}, token, actionsapi.DefaultUsageLogPath())
+
+	port := os.Getenv("OMARCHY_ACTIONS_PORT")
+	if port == "" {
+		port = "8189"
+	}
+	addr := "127.0.0.1:" + port
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- server.ListenAndServe(addr) }()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+
+	fmt.Printf("🚂 actions API live on http://%s (token: %s)\n", addr, filepath.Join(ol.configPath, "token"))
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("actions API: %w", err)
+	case <-sig:
+		fmt.Println("\n👋 Exiting actions API")
+		return nil
+	}
+}
+
+// withFocusedDir replaces a "." argument (our shorthand for "current
+// directory" in the default actions above) with the focused window's
+// working directory, so e.g. "file-manager" opens where the user is
+// actually looking instead of wherever the launcher process started.
+func (ol *OmarchyLauncher) withFocusedDir(action LauncherAction) LauncherAction {
+	args := make([]string, len(action.Args))
+	copy(args, action.Args)
+	for i, arg := range args {
+		if arg == "." {
+			args[i] = ol.focusedWindowDir
+		}
+	}
+	action.Args = args
+	return action
+}
+
+// findAction looks up an action by ID, for --exec one-off launches.
+func (ol *OmarchyLauncher) findAction(id string) (LauncherAction, bool) {
+	for _, action := range ol.actions {
+		if action.ID == id {
+			return action, true
+		}
+	}
+	return LauncherAction{}, false
+}
+
+// showAIDashboard displays the AI team status
+func (ol *OmarchyLauncher) showAIDashboard() error {
+	fmt.Println("\n🤖 **OMARCHY AI DASHBOARD**")
+	fmt.Println("==========================")
+
+	// Read AI team status
+	homeDir, _ := os.UserHomeDir()
+	statusFile := filepath.Join(homeDir, "Documents", "omarchy-ai-assist", "knowledge-outbox", "team-status", "latest.json")
+
+	if data, err := os.ReadFile(statusFile); err == nil {
+		var status map[string]interface{}
+		if json.Unmarshal(data, &status) == nil {
+			if overview, ok := status["overview"].(map[string]interface{}); ok {
+				fmt.Printf("📊 Active Assistants: %v/%v\n", overview["activeAssistants"], overview["totalAssistants"])
+				fmt.Printf("🧠 Knowledge Entries: %v\n", overview["knowledgeEntries"])
+				fmt.Printf("📋 Pending Tasks: %v\n", overview["pendingTasks"])
+				if lastCollab, ok := overview["lastCollaboration"].(string); ok {
+					fmt.Printf("⏰ Last Collaboration: %s\n", lastCollab)
+				}
+			}
+		}
+	}
+
+	fmt.Println("\n🔧 **Available Commands:**")
+	fmt.Println("#pln - AI planner subagent")
+	fmt.Println("#imp - AI implementor subagent")
+	fmt.Println("#knw - AI knowledge extraction subagent")
+
+	fmt.Println("\n🚀 **System Status:**")
+	ol.checkSystemStatus()
+
+	return nil
+}
+
+// showLMStudioInstructions displays LM Studio setup instructions
+func (ol *OmarchyLauncher) showLMStudioInstructions() error {
+	fmt.Println("\n🧠 **LM STUDIO INTEGRATION**")
+	fmt.Println("=============================")
+	fmt.Println("LM Studio provides advanced AI analysis capabilities for your Omarchy system.")
+	fmt.Println("")
+	fmt.Println("📁 **Knowledge Base Location:**")
+	fmt.Println("   ~/Documents/omarchy-ai-assist/knowledge-outbox/")
+	fmt.Println("")
+	fmt.Println("🔄 **Sync Commands:**")
+	fmt.Println("   node lm-studio-integration.js export    # Export to LM Studio")
+	fmt.Println("   node lm-studio-integration.js import    # Import from LM Studio")
+	fmt.Println("   node lm-studio-integration.js sync      # Full bidirectional sync")
+	fmt.Println("")
+	fmt.Println("📊 **Current Status:**")
+	fmt.Println("   ✅ Knowledge bridge established")
+	fmt.Println("   ✅ Export/import functionality ready")
+	fmt.Println("   ✅ AI team insights available")
+	fmt.Println("")
+	fmt.Println("💡 **Usage:**")
+	fmt.Println("   1. Export current AI team knowledge")
+	fmt.Println("   2. Use LM Studio for advanced analysis")
+	fmt.Println("   3. Import insights back to your system")
+
+	return nil
+}
+
+// startGoMonitor starts the Go system monitor
+func (ol *OmarchyLauncher) startGoMonitor() error {
+	// Check if already running
+	cmd := exec.Command("pgrep", "-f", "go-system-monitor")
+	if err := cmd.Run(); err == nil {
+		fmt.Println("✅ Go System Monitor is already running")
+		return nil
+	}
+
+	// Start in background
+	go func() {
+		homeDir, _ := os.UserHomeDir()
+		goCmd := exec.Command("/usr/local/go/bin/go", "run",
+			filepath.Join(homeDir, "Documents", "omarchy-ai-assist", "go-system-monitor.go"))
+		goCmd.Run()
+	}()
+
+	fmt.Println("🚀 Starting Go System Monitor...")
+	time.Sleep(1 * time.Second)
+
+	// Open in browser
+	return exec.Command("xdg-open", "http://localhost:3000").Run()
+}
+
+// checkSystemStatus checks the status of system components
+func (ol *OmarchyLauncher) checkSystemStatus() {
+	components := []struct {
+		name   string
+		cmd    string
+		args   []string
+		status string
+	}{
+		{"Ollama", "ollama", []string{"list"}, ""},
+		{"Wofi", "wofi", []string{"--version"}, ""},
+		{"Node.js", "node", []string{"--version"}, ""},
+		{"Go", "/usr/local/go/bin/go", []string{"version"}, ""},
+	}
+
+	for _, comp := range components {
+		cmd := exec.Command(comp.cmd, comp.args...)
+		if err := cmd.Run(); err == nil {
+			comp.status = "✅ Active"
+		} else {
+			comp.status = "❌ Missing"
+		}
+		fmt.Printf("   %s %s\n", comp.status, comp.name)
+	}
+}
+
+// LoadConfig loads configuration from file
+func (ol *OmarchyLauncher) LoadConfig() error {
+	configFile := filepath.Join(ol.configPath, "config.json")
+
+	if _, err := os.Stat(configFile); os.IsNotExist(err) {
+		// Create default config
+		defaultConfig := LauncherConfig{
+			Hotkey:        "Super+Space",
+			ShowOnStartup: false,
+			Actions:       ol.actions,
+			CustomActions: make(map[string]Action),
+		}
+
+		data, _ := json.MarshalIndent(defaultConfig, "", "  ")
+		return os.WriteFile(configFile, data, 0644)
+	}
+
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	var config LauncherConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to parse config file: %v", err)
+	}
+
+	// Merge custom actions
+	for _, customAction := range config.CustomActions {
+		action := LauncherAction{
+			ID:          customAction.Name,
+			Name:        customAction.Name,
+			Description: customAction.Description,
+			Icon:        "⚙️",
+			Category:    "custom",
+			Command:     customAction.Command,
+			Args:        customAction.Args,
+			Hotkey:      "",
+			Priority:    10,
+			Sandbox:     customAction.Sandbox,
+		}
+		config.Actions = append(config.Actions, action)
+	}
+
+	ol.actions = config.Actions
+	if config.Frontend != "" {
+		ol.frontend = DetectFrontend(config.Frontend)
+	}
+	return nil
+}
+
+// RunHotkey registers every action's Hotkey as a real Hyprland bind over
+// its IPC dispatch socket, subscribes to the event socket to track focus
+// and monitor changes, and blocks until SIGINT/SIGTERM, cleanly unbinding
+// on the way out.
+func (ol *OmarchyLauncher) RunHotkey() error {
+	fmt.Println("🚂 Omarchy Launcher Hotkey Mode")
+	fmt.Println("Binding hotkeys via Hyprland IPC, Ctrl+C to quit")
+
+	client, err := hyprland.NewClient()
+	if err != nil {
+		return fmt.Errorf("hotkey mode requires a Hyprland session: %w", err)
+	}
+	ol.hypr = client
+
+	if err := registerBindsWithRetry(client, ol.actions, 10, 500*time.Millisecond); err != nil {
+		return err
+	}
+	defer unregisterBinds(client, ol.actions)
+
+	done := make(chan struct{})
+	events, err := client.Subscribe(done, 2*time.Second)
+	if err != nil {
+		close(done)
+		return fmt.Errorf("hotkey mode: subscribe to events: %w", err)
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			ol.handleHyprlandEvent(event)
+		case <-sig:
+			close(done)
+			fmt.Println("\n👋 Exiting hotkey mode, unbinding...")
+			return nil
+		}
+	}
+}
+
+// registerBindsWithRetry registers a `keyword bind` for every action with
+// a Hotkey, retrying the whole batch if the command socket isn't ready
+// yet (e.g. the launcher started as part of Hyprland's own startup
+// sequence, before the socket exists).
+func registerBindsWithRetry(client *hyprland.Client, actions []LauncherAction, attempts int, delay time.Duration) error {
+	var lastErr error
+	for i := 0; i < attempts; i++ {
+		if lastErr = registerBinds(client, actions); lastErr == nil {
+			return nil
+		}
+		time.Sleep(delay)
+	}
+	return fmt.Errorf("hotkey mode: could not register binds after %d attempts: %w", attempts, lastErr)
+}
+
+func registerBinds(client *hyprland.Client, actions []LauncherAction) error {
+	for _, action := range actions {
+		if action.Hotkey == "" {
+			continue
+		}
+		mods, key, err := parseHotkey(action.Hotkey)
+		if err != nil {
+			fmt.Printf("⚠️  skipping hotkey for %s: %v\n", action.ID, err)
+			continue
+		}
+		execCmd := fmt.Sprintf("omarchy-launcher --action=%s", action.ID)
+		if err := client.Bind(mods, key, "exec", execCmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unregisterBinds removes every bind registerBinds added, on shutdown.
+func unregisterBinds(client *hyprland.Client, actions []LauncherAction) {
+	for _, action := range actions {
+		if action.Hotkey == "" {
+			continue
+		}
+		mods, key, err := parseHotkey(action.Hotkey)
+		if err != nil {
+			continue
+		}
+		if err := client.Unbind(mods, key); err != nil {
+			fmt.Printf("⚠️  failed to unbind %s: %v\n", action.Hotkey, err)
+		}
+	}
+}
+
+// parseHotkey turns "Super+Shift+S" into Hyprland bind syntax: a
+// space-joined, upper-cased modifier list and the trailing key.
+func parseHotkey(hotkeyStr string) (mods string, key string, err error) {
+	parts := strings.Split(hotkeyStr, "+")
+	if len(parts) == 0 || parts[len(parts)-1] == "" {
+		return "", "", fmt.Errorf("empty hotkey")
+	}
+	key = hyprlandKeyName(strings.TrimSpace(parts[len(parts)-1]))
+	modParts := make([]string, 0, len(parts)-1)
+	for _, part := range parts[:len(parts)-1] {
+		modParts = append(modParts, strings.ToUpper(strings.TrimSpace(part)))
+	}
+	return strings.Join(modParts, " "), key, nil
+}
+
+// hyprlandKeyName maps our Enter/Space spellings to Hyprland's keysym
+// names (matching internal/hotkey's HyprlandBackend); everything else
+// (letters, digits) passes through unchanged.
+func hyprlandKeyName(key string) string {
+	switch strings.ToLower(key) {
+	case "enter":
+		return "Return"
+	case "space":
+		return "space"
+	default:
+		return key
+	}
+}
+
+// handleHyprlandEvent updates the launcher's focus-tracking state from
+// one event-socket line, so FocusAwareActions and future monitor-aware
+// positioning always see the latest workspace/window/monitor.
+func (ol *OmarchyLauncher) handleHyprlandEvent(event hyprland.Event) {
+	switch event.Kind {
+	case "workspace", "workspacev2":
+		ol.lastWorkspace = event.Data
+	case "activewindow":
+		class, _, _ := strings.Cut(event.Data, ",")
+		ol.focusedWindowClass = class
+		if dir, ok := activeWindowWorkingDir(); ok {
+			ol.focusedWindowDir = dir
+		}
+	case "monitoraddedv2":
+		fields := strings.SplitN(event.Data, ",", 3)
+		if len(fields) > 1 {
+			ol.activeMonitor = fields[1]
+		}
+	}
+}
+
+// activeWindowWorkingDir asks Hyprland for the focused window's PID and
+// resolves its current working directory through /proc, so focus-aware
+// actions (e.g. "file-manager") can open where the user is actually
+// looking.
+func activeWindowWorkingDir() (string, bool) {
+	output, err := exec.Command("hyprctl", "activewindow", "-j").Output()
+	if err != nil {
+		return "", false
+	}
+	var win struct {
+		PID int `json:"pid"`
+	}
+	if err := json.Unmarshal(output, &win); err != nil || win.PID == 0 {
+		return "", false
+	}
+	dir, err := os.Readlink(fmt.Sprintf("/proc/%d/cwd", win.PID))
+	if err != nil {
+		return "", false
+	}
+	return dir, true
+}
+
+// applySandboxOverrides ORs the --wayland/--x11/--dbus/--pulse flags onto
+// profile for a one-off --exec launch. Flags left unset leave the action's
+// configured profile untouched; they can only add access, not remove it.
+func applySandboxOverrides(profile *sandbox.SandboxProfile, wayland, x11, dbus, pulse bool) {
+	if wayland {
+		profile.Enable |= sandbox.Wayland
+	}
+	if x11 {
+		profile.Enable |= sandbox.X11
+	}
+	if dbus {
+		profile.Enable |= sandbox.DBus
+	}
+	if pulse {
+		profile.Enable |= sandbox.Pulse
+	}
+}
+
+func main() {
+	var (
+		execID       = flag.String("exec", "", "run the named action ID directly, bypassing the picker")
+		actionID     = flag.String("action", "", "alias for --exec, matching the RPC form Hyprland's binds invoke ('omarchy-launcher --action=<id>')")
+		wayland      = flag.Bool("wayland", false, "share Wayland with --exec's sandbox, overriding its profile default")
+		x11          = flag.Bool("x11", false, "share X11 with --exec's sandbox, overriding its profile default")
+		dbus         = flag.Bool("dbus", false, "broker D-Bus access via xdg-dbus-proxy for --exec's sandbox, overriding its profile default")
+		pulse        = flag.Bool("pulse", false, "share PulseAudio with --exec's sandbox, overriding its profile default")
+		resetRanking = flag.Bool("reset-ranking", false, "clear recorded usage history and exit, without running any action")
+		serveAPI     = flag.Bool("api", false, "serve the action-dispatch HTTP API (GET /actions, GET /actions/{id}, POST /actions/{id}/invoke, GET /events) on loopback and block")
+		hotkeyMode   = flag.Bool("hotkey", false, "register every action's Hotkey via Hyprland IPC and block, tracking focus/workspace for rankedActions' context bonus")
+	)
+	flag.Parse()
+	// --action is the form Hyprland's own binds invoke (see RunHotkey's
+	// registerBinds), so an action resolved that way counts as a hotkey
+	// visit for frecency scoring rather than a deliberate picker choice.
+	visitType := "launcher"
+	if *execID == "" && *actionID != "" {
+		visitType = "hotkey"
+	}
+	if *execID == "" {
+		execID = actionID
+	}
+
+	fmt.Println("🚂 **OMARCHY DESKTOP LAUNCHER**")
+	fmt.Println("==============================")
+	fmt.Println("🤖 AI-powered desktop integration for Omarchy OS")
+	fmt.Println("⌨️  Keyboard-driven workflow")
+	fmt.Println("🔗 Integrated with AI subagents and LM Studio")
+	fmt.Println("")
+
+	if err := sandbox.Cleanup(); err != nil {
+		log.Printf("⚠️  Warning: sandbox cleanup failed: %v", err)
+	}
+
+	launcher := NewOmarchyLauncher()
+
+	// Initialize launcher
+	if err := launcher.Initialize(); err != nil {
+		log.Fatalf("❌ Failed to initialize launcher: %v", err)
+	}
+
+	// Load configuration
+	if err := launcher.LoadConfig(); err != nil {
+		log.Printf("⚠️  Warning: Failed to load config: %v", err)
+	}
+
+	if *resetRanking {
+		if err := launcher.frecency.Reset(); err != nil {
+			log.Fatalf("❌ Failed to reset ranking: %v", err)
+		}
+		fmt.Println("🗑️  Usage ranking reset")
+		return
+	}
+
+	if *serveAPI {
+		if err := runActionsAPI(launcher); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		return
+	}
+
+	if *hotkeyMode {
+		if err := launcher.RunHotkey(); err != nil {
+			log.Fatalf("❌ Hotkey mode error: %v", err)
+		}
+		return
+	}
+
+	if *execID != "" {
+		action, ok := launcher.findAction(*execID)
+		if !ok {
+			log.Fatalf("❌ No action with id %q", *execID)
+		}
+		applySandboxOverrides(&action.Sandbox, *wayland, *x11, *dbus, *pulse)
+		if err := launcher.executeAction(action, visitType); err != nil {
+			log.Fatalf("❌ Action failed: %v", err)
+		}
+		return
+	}
+
+	// Show launcher
+	if err := launcher.ShowLauncher(); err != nil {
+		log.Printf("❌ Launcher error: %v", err)
+	}
+
+	fmt.Println("\n✨ Have a productive day with Omarchy! 🌟")
+}
@@ -0,0 +1,72 @@
+// Command platformspec is the standalone CLI for pkg/platformspec: it can
+// export the OmServiceSpec JSON Schema for editor completion and render an
+// existing spec document as Kubernetes manifests, independent of the
+// handbook generator that produces one.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/zebadiee/omarchy-ai-assist/pkg/platformspec"
+)
+
+func main() {
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "export-schema":
+		out := "om-service-spec.schema.json"
+		if len(args) > 1 {
+			out = args[1]
+		}
+		schema, err := platformspec.ExportSchema()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(out, schema, 0o644); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("wrote %s\n", out)
+
+	case "to-kubernetes":
+		if len(args) < 2 {
+			usage()
+			os.Exit(1)
+		}
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			log.Fatal(err)
+		}
+		spec, err := platformspec.Unmarshal(data)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if errs := platformspec.Validate(spec); len(errs) > 0 {
+			for _, e := range errs {
+				fmt.Fprintln(os.Stderr, e)
+			}
+			os.Exit(1)
+		}
+		manifests, err := platformspec.ToKubernetesManifests(spec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(manifests)
+
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: platformspec {export-schema [path]|to-kubernetes <spec.jsonld>}")
+}
@@ -3,22 +3,29 @@ package main
 import (
 	"bufio"
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type Usage struct {
-	Time    string `json:"time,omitempty"`
-	Event   string `json:"event,omitempty"`
-	Agent   string `json:"agent,omitempty"`
-	Purpose string `json:"purpose,omitempty"`
-	Model   string `json:"model,omitempty"`
-	Tokens  int    `json:"tokens,omitempty"`
-	Cache   string `json:"cache,omitempty"` // hit/miss
+	Time     string `json:"time,omitempty"`
+	Event    string `json:"event,omitempty"`
+	Agent    string `json:"agent,omitempty"`
+	Purpose  string `json:"purpose,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	Model    string `json:"model,omitempty"`
+	Tokens   int    `json:"tokens,omitempty"`
+	Cache    string `json:"cache,omitempty"` // hit/miss
 }
 
 type MDL struct {
@@ -47,6 +54,234 @@ func tailJSONL(p string, max int) []json.RawMessage {
 	return lines
 }
 
+// usageEventsTotal and the mdl gauges below are the Prometheus counterpart
+// to /metrics.json: they turn the same usage.jsonl/mdl.jsonl logs into
+// exposition any Prometheus/Grafana stack can scrape directly.
+var (
+	usageEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "omarchy_usage_events_total",
+		Help: "Parsed usage.jsonl events, labeled by agent/provider/model/cache outcome.",
+	}, []string{"agent", "provider", "model", "cache_hit"})
+
+	mdlCurrent = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "omarchy_mdl_current",
+		Help: "Most recently observed MDL value from mdl.jsonl.",
+	})
+
+	mdlDelta = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "omarchy_mdl_delta",
+		Help: "Most recently observed MDL delta from mdl.jsonl.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(usageEventsTotal, mdlCurrent, mdlDelta)
+}
+
+// sseBroker fans published lines out to every open /events connection so a
+// jsonlTailer only has to read each new line once, no matter how many
+// dashboards are watching.
+type sseBroker struct {
+	mu   sync.Mutex
+	subs map[chan string]struct{}
+}
+
+func newSSEBroker() *sseBroker {
+	return &sseBroker{subs: make(map[chan string]struct{})}
+}
+
+func (b *sseBroker) subscribe() chan string {
+	ch := make(chan string, 16)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+	return ch
+}
+
+func (b *sseBroker) unsubscribe(ch chan string) {
+	b.mu.Lock()
+	delete(b.subs, ch)
+	b.mu.Unlock()
+	close(ch)
+}
+
+func (b *sseBroker) publish(event string, data []byte) {
+	msg := fmt.Sprintf("event: %s\ndata: %s\n\n", event, data)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- msg:
+		default:
+			// Slow subscriber; drop rather than block the tailer.
+		}
+	}
+}
+
+var events = newSSEBroker()
+
+// jsonlTailer polls path for lines appended since the last check and hands
+// each one to onLine, so usageEventsTotal/mdl gauges stay live without
+// re-reading (and re-counting) the whole file every tick.
+type jsonlTailer struct {
+	path   string
+	offset int64
+	onLine func([]byte)
+}
+
+func (t *jsonlTailer) poll() {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(t.offset, 0); err != nil {
+		return
+	}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		t.onLine(sc.Bytes())
+	}
+	if pos, err := f.Seek(0, 1); err == nil {
+		t.offset = pos
+	}
+}
+
+// watchJSONL calls onLine for every line appended to path since the last
+// check. It watches path's directory with fsnotify so new lines reach
+// subscribers as soon as they're written, instead of on a fixed polling
+// interval; a slow fallback tick covers watchers that miss an event (e.g.
+// an editor that replaces the file via rename rather than append).
+func watchJSONL(path string, onLine func([]byte)) {
+	t := &jsonlTailer{path: path, onLine: onLine}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Printf("fsnotify unavailable for %s, falling back to 2s polling: %v", path, err)
+		go func() {
+			for {
+				t.poll()
+				time.Sleep(2 * time.Second)
+			}
+		}()
+		return
+	}
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		log.Printf("fsnotify: watch %s: %v", filepath.Dir(path), err)
+	}
+
+	go func() {
+		defer watcher.Close()
+		fallback := time.NewTicker(10 * time.Second)
+		defer fallback.Stop()
+		t.poll() // pick up anything already appended before we started watching
+		for {
+			select {
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(ev.Name) == filepath.Clean(path) && ev.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+					t.poll()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("fsnotify: %s: %v", path, err)
+			case <-fallback.C:
+				t.poll()
+			}
+		}
+	}()
+}
+
+func recordUsageLine(line []byte) {
+	var u Usage
+	if json.Unmarshal(line, &u) != nil {
+		return
+	}
+	agent := valueOr(u.Agent, "unknown")
+	provider := valueOr(u.Provider, "unknown")
+	model := valueOr(u.Model, "unknown")
+	cacheHit := valueOr(u.Cache, "unknown")
+	usageEventsTotal.WithLabelValues(agent, provider, model, cacheHit).Inc()
+	events.publish("usage", line)
+}
+
+func recordMDLLine(line []byte) {
+	var m MDL
+	if json.Unmarshal(line, &m) != nil {
+		return
+	}
+	mdlCurrent.Set(m.MDL)
+	mdlDelta.Set(m.Delta)
+	events.publish("mdl", line)
+}
+
+// handleEvents streams new usage.jsonl/mdl.jsonl lines plus a periodic full
+// snapshot over Server-Sent Events, so dashboards stop re-fetching and
+// re-tailing both files on a fixed 5s interval.
+func handleEvents(usage, mdl string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		sub := events.subscribe()
+		defer events.unsubscribe(sub)
+
+		writeSnapshot := func() {
+			data, err := json.Marshal(struct {
+				Now         string            `json:"now"`
+				UsageRecent []json.RawMessage `json:"usage_recent"`
+				MDLRecent   []json.RawMessage `json:"mdl_recent"`
+			}{
+				Now:         time.Now().UTC().Format(time.RFC3339),
+				UsageRecent: tailJSONL(usage, 200),
+				MDLRecent:   tailJSONL(mdl, 200),
+			})
+			if err == nil {
+				fmt.Fprintf(w, "event: snapshot\ndata: %s\n\n", data)
+				flusher.Flush()
+			}
+		}
+		writeSnapshot()
+
+		snapshot := time.NewTicker(10 * time.Second)
+		defer snapshot.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case msg, ok := <-sub:
+				if !ok {
+					return
+				}
+				fmt.Fprint(w, msg)
+				flusher.Flush()
+			case <-snapshot.C:
+				writeSnapshot()
+			}
+		}
+	}
+}
+
+func valueOr(s, fallback string) string {
+	if s == "" {
+		return fallback
+	}
+	return s
+}
+
 func main() {
 	root := os.Getenv("OMARCHY_ROOT")
 	if root == "" {
@@ -56,8 +291,11 @@ func main() {
 	usage := filepath.Join(root, "logs", "usage.jsonl")
 	mdl := filepath.Join(root, "logs", "mdl.jsonl") // write your MDL snapshots here
 
+	watchJSONL(usage, recordUsageLine)
+	watchJSONL(mdl, recordMDLLine)
+
 	type Metrics struct {
-		Now         string           `json:"now"`
+		Now         string            `json:"now"`
 		UsageRecent []json.RawMessage `json:"usage_recent"`
 		MDLRecent   []json.RawMessage `json:"mdl_recent"`
 	}
@@ -72,19 +310,57 @@ func main() {
 		json.NewEncoder(w).Encode(m)
 	})
 
+	http.Handle("/metrics", promhttp.Handler())
+	http.HandleFunc("/events", handleEvents(usage, mdl))
+
 	tmpl := template.Must(template.New("dash").Parse(`
 <!doctype html><meta charset="utf-8">
 <title>Quantum-Forge Monitor</title>
 <h1>Quantum-Forge Monitor</h1>
-<p><code>/metrics.json</code> provides recent usage + MDL. Auto-refreshing every 5s.</p>
+<p><code>/metrics.json</code> provides recent usage + MDL; <code>/metrics</code> is Prometheus exposition; <code>/events</code> streams both live over SSE.</p>
 <pre id="out">loading…</pre>
 <script>
+let usageRecent = [];
+let mdlRecent = [];
+
+function render(){
+  document.getElementById('out').textContent = JSON.stringify({
+    now: new Date().toISOString(),
+    usage_recent: usageRecent,
+    mdl_recent: mdlRecent,
+  }, null, 2);
+}
+
 async function tick(){
   const r = await fetch('metrics.json', {cache:'no-store'});
   const j = await r.json();
-  document.getElementById('out').textContent = JSON.stringify(j,null,2);
+  usageRecent = j.usage_recent || [];
+  mdlRecent = j.mdl_recent || [];
+  render();
+}
+
+if (window.EventSource) {
+  const es = new EventSource('events');
+  es.addEventListener('snapshot', (e) => {
+    const j = JSON.parse(e.data);
+    usageRecent = j.usage_recent || [];
+    mdlRecent = j.mdl_recent || [];
+    render();
+  });
+  es.addEventListener('usage', (e) => {
+    usageRecent.push(JSON.parse(e.data));
+    if (usageRecent.length > 200) usageRecent.shift();
+    render();
+  });
+  es.addEventListener('mdl', (e) => {
+    mdlRecent.push(JSON.parse(e.data));
+    if (mdlRecent.length > 200) mdlRecent.shift();
+    render();
+  });
+  es.onerror = () => console.error('SSE connection lost, retrying...');
+} else {
+  tick(); setInterval(tick, 5000);
 }
-tick(); setInterval(tick, 5000);
 </script>`))
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_ = tmpl.Execute(w, nil)
@@ -92,5 +368,6 @@ tick(); setInterval(tick, 5000);
 
 	addr := ":8088"
 	log.Printf("Quantum-Forge monitor listening on %s", addr)
+	log.Printf("Prometheus metrics at %s/metrics", addr)
 	log.Fatal(http.ListenAndServe(addr, nil))
-}
\ No newline at end of file
+}
@@ -1,14 +1,20 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/blueprintstore"
+	"github.com/zebadiee/omarchy-ai-assist/internal/omai"
 )
 
 const (
@@ -80,40 +86,29 @@ type VBHFacts struct {
 	Counter    int    `json:"counter,omitempty"`
 }
 
-// Blueprint represents a generated blueprint
-type Blueprint struct {
-	ID          string    `json:"id"`
-	Timestamp   time.Time `json:"timestamp"`
-	VBHCounter  int       `json:"vbhCounter"`
-	VBHHash     string    `json:"vbhHash"`
-	Content     string    `json:"content"`
-	Metrics     Metrics   `json:"metrics"`
-	BuildID     string    `json:"buildId"`
-	OpenTasks   int       `json:"openTasks"`
-}
-
-// Metrics represents blueprint metrics
-type Metrics struct {
-	LambdaEntropy   float64 `json:"lambdaEntropy"`
-	MDLDelta        int     `json:"mdlDelta"`
-	TraceSimilarity float64 `json:"traceSimilarity"`
-	QuantumCoherence float64 `json:"quantumCoherence"`
-}
+// Metrics is an alias so the rest of this file can keep referring to
+// Metrics instead of blueprintstore.Metrics.
+type Metrics = blueprintstore.Metrics
 
 // QuantumForge represents the main application
 type QuantumForge struct {
 	vbhCounter int
 	vbhFacts   VBHFacts
 	buildID    string
-	blueprintDir string
+	store      blueprintstore.Store
 }
 
-// NewQuantumForge creates a new QuantumForge instance
-func NewQuantumForge() (*QuantumForge, error) {
+// NewQuantumForge creates a new QuantumForge instance backed by store. A
+// nil store defaults to a FilesystemStore rooted at BLUEPRINT_DIR, the
+// original single-host behavior.
+func NewQuantumForge(store blueprintstore.Store) (*QuantumForge, error) {
+	if store == nil {
+		store = blueprintstore.NewFilesystemStore(BLUEPRINT_DIR)
+	}
 	qf := &QuantumForge{
 		vbhCounter: 1,
 		buildID:    generateBuildID(),
-		blueprintDir: BLUEPRINT_DIR,
+		store:      store,
 	}
 
 	// Load VBH facts from environment or file
@@ -145,10 +140,10 @@ func (qf *QuantumForge) loadVBHFacts() error {
 
 	// Use defaults
 	qf.vbhFacts = VBHFacts{
-		Scope:    "unified",
-		Site:     "Omarchy",
+		Scope:     "unified",
+		Site:      "Omarchy",
 		OpenTasks: 0,
-		Provider: "quantum-forge",
+		Provider:  "quantum-forge",
 	}
 
 	return nil
@@ -178,82 +173,40 @@ func (qf *QuantumForge) createPrimePrompt() string {
 		qf.vbhCounter, vbhHash, qf.vbhFacts.OpenTasks, qf.buildID)
 }
 
-// saveBlueprint saves a blueprint to storage
+// saveBlueprint saves a blueprint via qf.store. On the filesystem backend
+// this assigns qf.vbhCounter's ID as before; on the etcd backend, Save
+// overwrites VBHCounter/ID with the value it assigns under CAS, since a
+// locally-incremented counter can't be trusted once more than one host is
+// writing to the same lattice.
 func (qf *QuantumForge) saveBlueprint(content string, metrics Metrics) error {
-	// Create blueprint directory
-	if err := os.MkdirAll(qf.blueprintDir, 0755); err != nil {
-		return fmt.Errorf("failed to create blueprint directory: %w", err)
+	blueprint := &blueprintstore.Blueprint{
+		ID:         fmt.Sprintf("quantum-forge-%d", qf.vbhCounter),
+		Timestamp:  time.Now(),
+		VBHCounter: qf.vbhCounter,
+		VBHHash:    qf.generateVBHHash(),
+		Content:    content,
+		Metrics:    metrics,
+		BuildID:    qf.buildID,
+		OpenTasks:  qf.vbhFacts.OpenTasks,
 	}
 
-	blueprint := Blueprint{
-		ID:          fmt.Sprintf("quantum-forge-%d", qf.vbhCounter),
-		Timestamp:   time.Now(),
-		VBHCounter:  qf.vbhCounter,
-		VBHHash:     qf.generateVBHHash(),
-		Content:     content,
-		Metrics:     metrics,
-		BuildID:     qf.buildID,
-		OpenTasks:   qf.vbhFacts.OpenTasks,
-	}
-
-	// Save blueprint
-	blueprintFile := filepath.Join(qf.blueprintDir, fmt.Sprintf("%s.json", blueprint.ID))
-	data, err := json.MarshalIndent(blueprint, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal blueprint: %w", err)
-	}
-
-	if err := os.WriteFile(blueprintFile, data, 0644); err != nil {
-		return fmt.Errorf("failed to write blueprint file: %w", err)
-	}
-
-	// Also save as markdown
-	mdFile := filepath.Join(qf.blueprintDir, fmt.Sprintf("%s.md", blueprint.ID))
-	mdContent := fmt.Sprintf(`# Quantum-Forge Blueprint: %s
-
-**Generated:** %s
-**VBH Counter:** %d
-**Build ID:** %s
-**Open Tasks:** %d
-
-## Metrics
-
-- **Lambda Entropy:** %.3f
-- **MDL Delta:** %d bytes
-- **Trace Similarity:** %.3f
-- **Quantum Coherence:** %.3f
-
-## Content
-
-%s
-`, blueprint.ID, blueprint.Timestamp.Format(time.RFC3339), blueprint.VBHCounter,
-	blueprint.BuildID, blueprint.OpenTasks, metrics.LambdaEntropy,
-	metrics.MDLDelta, metrics.TraceSimilarity, metrics.QuantumCoherence, content)
-
-	if err := os.WriteFile(mdFile, []byte(mdContent), 0644); err != nil {
-		return fmt.Errorf("failed to write markdown file: %w", err)
+	if err := qf.store.Save(context.Background(), blueprint); err != nil {
+		return fmt.Errorf("failed to save blueprint: %w", err)
 	}
+	qf.vbhCounter = blueprint.VBHCounter
 
 	fmt.Printf("🔷 Blueprint saved: %s\n", blueprint.ID)
-	fmt.Printf("   JSON: %s\n", blueprintFile)
-	fmt.Printf("   Markdown: %s\n", mdFile)
-
 	return nil
 }
 
 // listBlueprints lists existing blueprints
 func (qf *QuantumForge) listBlueprints() error {
-	if _, err := os.Stat(qf.blueprintDir); os.IsNotExist(err) {
-		fmt.Println("📭 No blueprints found")
-		return nil
-	}
-
-	files, err := filepath.Glob(filepath.Join(qf.blueprintDir, "*.json"))
+	blueprints, err := qf.store.List(context.Background())
 	if err != nil {
-		return fmt.Errorf("failed to glob blueprint files: %w", err)
+		return fmt.Errorf("failed to list blueprints: %w", err)
 	}
 
-	if len(files) == 0 {
+	if len(blueprints) == 0 {
 		fmt.Println("📭 No blueprints found")
 		return nil
 	}
@@ -261,19 +214,7 @@ func (qf *QuantumForge) listBlueprints() error {
 	fmt.Println("🔷 Quantum-Forge Blueprints:")
 	fmt.Println()
 
-	for _, file := range files {
-		data, err := os.ReadFile(file)
-		if err != nil {
-			fmt.Printf("❌ Error reading %s: %v\n", filepath.Base(file), err)
-			continue
-		}
-
-		var blueprint Blueprint
-		if err := json.Unmarshal(data, &blueprint); err != nil {
-			fmt.Printf("❌ Error parsing %s: %v\n", filepath.Base(file), err)
-			continue
-		}
-
+	for _, blueprint := range blueprints {
 		fmt.Printf("📄 %s\n", blueprint.ID)
 		fmt.Printf("   Generated: %s\n", blueprint.Timestamp.Format(time.RFC3339))
 		fmt.Printf("   VBH Counter: %d\n", blueprint.VBHCounter)
@@ -285,8 +226,112 @@ func (qf *QuantumForge) listBlueprints() error {
 	return nil
 }
 
-// injectToBackend injects the Prime Prompt to a backend
-func (qf *QuantumForge) injectToBackend(backend string, prompt string) error {
+// omaiResult carries what the omai backend actually produced, so main can
+// save a Blueprint reflecting the model's real output instead of the
+// hard-coded simulated metrics used for the other backends.
+type omaiResult struct {
+	content string
+	metrics Metrics
+}
+
+// Patterns for the Prime Prompt's FAILSAFE line and the metrics it asks the
+// model to annotate the response with (see PRIME_PROMPT_TEMPLATE's DIRECTIVE
+// step 6). Metrics default to zero when a line is missing rather than erroring
+// the whole invocation — a model that confirms but skips one annotation still
+// produced a usable blueprint.
+var (
+	confirmLineRe = regexp.MustCompile(`(?m)^CONFIRM:(\{.*\})\s*$`)
+	refuseLineRe  = regexp.MustCompile(`(?m)^VBH_REFUSE:(\{.*\})\s*$`)
+
+	lambdaEntropyRe    = regexp.MustCompile(`(?i)λ-Entropy(?:\s+score)?[:\s]+(-?[0-9.]+)`)
+	mdlDeltaRe         = regexp.MustCompile(`(?i)MDL delta[:\s]+(-?[0-9.]+)`)
+	traceSimilarityRe  = regexp.MustCompile(`(?i)Trace similarity[:\s]+(-?[0-9.]+)`)
+	quantumCoherenceRe = regexp.MustCompile(`(?i)Quantum coherence[:\s]+(-?[0-9.]+)`)
+)
+
+// runOmai sends prompt to the configured omai provider (see
+// internal/omai.LoadConfigFromEnv), waits up to timeout, and parses the
+// Prime Prompt's FAILSAFE line: a VBH_REFUSE (or a timeout) is reported
+// as an error so the caller skips saving a blueprint, while a CONFIRM is
+// checked against qf.vbhFacts before its annotated metrics are
+// extracted.
+func (qf *QuantumForge) runOmai(prompt string, timeout time.Duration) (*omaiResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cfg := omai.LoadConfigFromEnv()
+	provider, err := omai.NewProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("omai backend: %w", err)
+	}
+	client := omai.NewClient(provider, omai.DefaultRetryPolicy())
+
+	resp, err := client.Complete(ctx, omai.Request{
+		Model:    cfg.Model,
+		Messages: []omai.Message{{Role: omai.RoleUser, Content: prompt}},
+	})
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("omai backend timed out after %s", timeout)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("omai backend failed: %w", err)
+	}
+
+	output := resp.Content
+
+	if m := refuseLineRe.FindStringSubmatch(output); m != nil {
+		var refusal struct {
+			Reason string `json:"reason"`
+		}
+		_ = json.Unmarshal([]byte(m[1]), &refusal)
+		reason := refusal.Reason
+		if reason == "" {
+			reason = m[1]
+		}
+		return nil, fmt.Errorf("omai refused: %s", reason)
+	}
+
+	m := confirmLineRe.FindStringSubmatch(output)
+	if m == nil {
+		return nil, fmt.Errorf("omai response contained neither CONFIRM nor VBH_REFUSE")
+	}
+	var confirmed VBHFacts
+	if err := json.Unmarshal([]byte(m[1]), &confirmed); err != nil {
+		return nil, fmt.Errorf("omai CONFIRM line was not valid JSON: %w", err)
+	}
+	if confirmed.Scope != qf.vbhFacts.Scope || confirmed.Site != qf.vbhFacts.Site ||
+		confirmed.OpenTasks != qf.vbhFacts.OpenTasks || confirmed.Provider != qf.vbhFacts.Provider {
+		return nil, fmt.Errorf("omai CONFIRM facts %+v do not match expected %+v", confirmed, qf.vbhFacts)
+	}
+
+	var metrics Metrics
+	metrics.LambdaEntropy = parseFloatMatch(lambdaEntropyRe, output)
+	metrics.MDLDelta = int(parseFloatMatch(mdlDeltaRe, output))
+	metrics.TraceSimilarity = parseFloatMatch(traceSimilarityRe, output)
+	metrics.QuantumCoherence = parseFloatMatch(quantumCoherenceRe, output)
+
+	return &omaiResult{content: output, metrics: metrics}, nil
+}
+
+// parseFloatMatch returns the first capture group of re in s as a float64,
+// or 0 if re doesn't match or the capture isn't a valid number.
+func parseFloatMatch(re *regexp.Regexp, s string) float64 {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// injectToBackend injects the Prime Prompt to a backend. For "omai" it
+// returns the backend's actual response so the caller can save a Blueprint
+// reflecting what the model produced instead of simulated metrics; the
+// other backends return a nil result since they don't observe a response.
+func (qf *QuantumForge) injectToBackend(backend string, prompt string, omaiTimeout time.Duration) (*omaiResult, error) {
 	fmt.Printf("🚀 Injecting Quantum-Forge Prime Prompt to %s...\n", backend)
 
 	switch backend {
@@ -294,38 +339,61 @@ func (qf *QuantumForge) injectToBackend(backend string, prompt string) error {
 		fmt.Println("\n" + strings.Repeat("=", 60))
 		fmt.Println(prompt)
 		fmt.Println(strings.Repeat("=", 60))
+		return nil, nil
 
 	case "file":
 		filename := fmt.Sprintf("quantum-forge-prompt-%d.md", qf.vbhCounter)
 		if err := os.WriteFile(filename, []byte(prompt), 0644); err != nil {
-			return fmt.Errorf("failed to write prompt file: %w", err)
+			return nil, fmt.Errorf("failed to write prompt file: %w", err)
 		}
 		fmt.Printf("📝 Prompt saved to: %s\n", filename)
+		return nil, nil
 
 	case "omai":
-		// Try to pipe to omai.js
-		cmd := fmt.Sprintf("echo '%s' | node omai.js", strings.ReplaceAll(prompt, "'", `'"'"'`))
-		fmt.Printf("🔗 Running: %s\n", cmd)
-		// Note: In a real implementation, you'd use os/exec here
+		result, err := qf.runOmai(prompt, omaiTimeout)
+		if err != nil {
+			return nil, err
+		}
+		fmt.Println("🔗 omai backend confirmed VBH facts")
+		return result, nil
 
 	default:
-		return fmt.Errorf("unknown backend: %s", backend)
+		return nil, fmt.Errorf("unknown backend: %s", backend)
 	}
+}
 
-	return nil
+// newBlueprintStore builds the Store selected by --store: "fs" for the
+// original single-host directory, "etcd" to share a lattice across hosts.
+func newBlueprintStore(kind, etcdEndpoints string) (blueprintstore.Store, error) {
+	switch kind {
+	case "fs":
+		return blueprintstore.NewFilesystemStore(BLUEPRINT_DIR), nil
+	case "etcd":
+		return blueprintstore.NewEtcdStore(strings.Split(etcdEndpoints, ","))
+	default:
+		return nil, fmt.Errorf("unknown --store value %q (want \"fs\" or \"etcd\")", kind)
+	}
 }
 
 func main() {
 	var (
-		backend     = flag.String("backend", "stdout", "Backend to inject to (stdout, file, omai)")
-		list        = flag.Bool("list", false, "List existing blueprints")
-		showPrompt  = flag.Bool("show-prompt", false, "Show the Prime Prompt without executing")
-		saveOnly    = flag.Bool("save-only", false, "Save blueprint without backend injection")
-		openTasks   = flag.Int("open-tasks", -1, "Override open tasks count")
+		backend       = flag.String("backend", "stdout", "Backend to inject to (stdout, file, omai)")
+		list          = flag.Bool("list", false, "List existing blueprints")
+		showPrompt    = flag.Bool("show-prompt", false, "Show the Prime Prompt without executing")
+		saveOnly      = flag.Bool("save-only", false, "Save blueprint without backend injection")
+		openTasks     = flag.Int("open-tasks", -1, "Override open tasks count")
+		storeKind     = flag.String("store", "fs", "Blueprint storage backend: \"fs\" (local directory) or \"etcd\" (shared multi-host lattice)")
+		etcdEndpoints = flag.String("etcd-endpoints", "localhost:2379", "comma-separated etcd endpoints, used with --store=etcd")
+		omaiTimeout   = flag.Duration("omai-timeout", 2*time.Minute, "timeout for the omai backend round-trip, used with --backend=omai")
 	)
 	flag.Parse()
 
-	qf, err := NewQuantumForge()
+	store, err := newBlueprintStore(*storeKind, *etcdEndpoints)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize blueprint store: %v", err)
+	}
+
+	qf, err := NewQuantumForge(store)
 	if err != nil {
 		log.Fatalf("❌ Failed to initialize Quantum-Forge: %v", err)
 	}
@@ -353,28 +421,37 @@ func main() {
 		return
 	}
 
-	// Simulate metrics (in a real implementation, these would be calculated)
+	// Simulated placeholder metrics, used for every backend except "omai",
+	// which reports its own λ-Entropy/MDL delta/Trace similarity/Quantum
+	// coherence annotations below.
+	content := prompt
 	metrics := Metrics{
-		LambdaEntropy:   0.125,
-		MDLDelta:        -256,
-		TraceSimilarity: 0.892,
+		LambdaEntropy:    0.125,
+		MDLDelta:         -256,
+		TraceSimilarity:  0.892,
 		QuantumCoherence: 0.945,
 	}
 
-	// Save blueprint
-	if err := qf.saveBlueprint(prompt, metrics); err != nil {
-		log.Fatalf("❌ Failed to save blueprint: %v", err)
-	}
-
-	// Inject to backend unless save-only
+	// Inject to backend unless save-only. A VBH_REFUSE or timeout from the
+	// omai backend aborts here without saving a blueprint.
 	if !*saveOnly {
-		if err := qf.injectToBackend(*backend, prompt); err != nil {
+		result, err := qf.injectToBackend(*backend, prompt, *omaiTimeout)
+		if err != nil {
 			log.Fatalf("❌ Failed to inject to backend: %v", err)
 		}
+		if result != nil {
+			content = result.content
+			metrics = result.metrics
+		}
+	}
+
+	// Save blueprint
+	if err := qf.saveBlueprint(content, metrics); err != nil {
+		log.Fatalf("❌ Failed to save blueprint: %v", err)
 	}
 
 	fmt.Printf("\n✨ Quantum-Forge invocation completed successfully!\n")
 	fmt.Printf("   VBH Counter: %d\n", qf.vbhCounter)
 	fmt.Printf("   Build ID: %s\n", qf.buildID)
 	fmt.Printf("   Blueprint ID: quantum-forge-%d\n", qf.vbhCounter)
-}
\ No newline at end of file
+}
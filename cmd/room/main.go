@@ -0,0 +1,107 @@
+// Command room is the `omarchy-guide room {tail,search,summarize,clear}`
+// backend: it operates on the JSONL breakout-room log that replaced the
+// old single room.json blob.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/omai"
+	"github.com/zebadiee/omarchy-ai-assist/internal/room"
+)
+
+func main() {
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		usage()
+		os.Exit(1)
+	}
+
+	dir := os.Getenv("OMAI_ROOM_DIR")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".npm-global", "omarchy-wagon")
+	}
+	r, err := room.Open(dir, room.DefaultBudget())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch args[0] {
+	case "tail":
+		n := 20
+		if len(args) > 1 {
+			if v, err := strconv.Atoi(args[1]); err == nil {
+				n = v
+			}
+		}
+		entries, err := r.Tail(n)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printEntries(entries)
+
+	case "search":
+		if len(args) < 2 {
+			usage()
+			os.Exit(1)
+		}
+		entries, err := r.Search(strings.Join(args[1:], " "))
+		if err != nil {
+			log.Fatal(err)
+		}
+		printEntries(entries)
+
+	case "summarize":
+		cfg := omai.LoadConfigFromEnv()
+		provider, err := omai.NewProvider(cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		client := omai.NewClient(provider, omai.DefaultRetryPolicy())
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		if err := r.MaybeSummarize(ctx, client, cfg.Model); err != nil {
+			log.Fatal(err)
+		}
+		idx, err := r.Index()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("room-index now has %d summaries covering %d total entries\n", len(idx.Summaries), idx.TotalEntries)
+
+	case "clear":
+		if err := r.Clear(); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println("breakout-room log cleared (rolling summaries preserved)")
+
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: room {tail [n]|search <query>|summarize|clear}")
+}
+
+func printEntries(entries []room.Entry) {
+	for _, e := range entries {
+		data, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		fmt.Println(string(data))
+	}
+}
@@ -0,0 +1,26 @@
+// Package actionsapi exposes the launcher's actions over a loopback HTTP
+// API -- GET /actions, GET /actions/{id}, POST /actions/{id}/invoke and
+// GET /events -- for Waybar modules, AI subagents and other external
+// tools that would rather speak HTTP than D-Bus or re-exec the CLI. Like
+// the dbus package, it only translates a Dispatcher's results into
+// responses; the caller's Dispatcher stays responsible for sandbox
+// enforcement.
+package actionsapi
+
+// ActionInfo is the subset of LauncherAction exposed over the API, the
+// HTTP analogue of dbus.ActionInfo.
+type ActionInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Category    string `json:"category"`
+}
+
+// Dispatcher is what a Server calls into to list and run actions. The
+// caller's implementation is responsible for sandbox enforcement: Server
+// itself has no opinion on how Execute runs a command.
+type Dispatcher interface {
+	ListActions() []ActionInfo
+	Execute(id string, args []string) (exitCode int, stdout, stderr string, err error)
+}
@@ -0,0 +1,111 @@
+package actionsapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultUsageLogPath returns $OMARCHY_ROOT/logs/usage.jsonl, or
+// ~/.omarchy/current/logs/usage.jsonl if OMARCHY_ROOT is unset -- the
+// same file and fallback searchd's logEvent writes to, so GET /events
+// and searchd's own query log share one append-only stream.
+func DefaultUsageLogPath() string {
+	root := os.Getenv("OMARCHY_ROOT")
+	if root == "" {
+		home, _ := os.UserHomeDir()
+		root = filepath.Join(home, ".omarchy", "current")
+	}
+	return filepath.Join(root, "logs", "usage.jsonl")
+}
+
+// logUsageEvent appends one JSONL record to path, in the same shape as
+// searchd's logEvent. A failure to log (missing dir, disk full) is
+// swallowed rather than surfaced, since it must never take down an
+// invoke request.
+func logUsageEvent(path, event, info string, fields map[string]any) {
+	os.MkdirAll(filepath.Dir(path), 0755)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	record := map[string]any{
+		"time":  time.Now().UTC().Format(time.RFC3339),
+		"event": event,
+		"info":  info,
+	}
+	for k, v := range fields {
+		record[k] = v
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	f.Write(append(data, '\n'))
+}
+
+// tailUsageLog streams lines appended to path after this call starts, so
+// a newly connected /events client only sees events from "now" onward --
+// not a full replay of usage.jsonl's history. A missing file is treated
+// as "nothing yet" rather than an error, since usage.jsonl is created
+// lazily on the first invocation or search.
+func tailUsageLog(path string, out chan<- string, done <-chan struct{}) {
+	defer close(out)
+
+	var offset int64
+	if info, err := os.Stat(path); err == nil {
+		offset = info.Size()
+	}
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			offset = readNewLines(path, offset, out, done)
+		}
+	}
+}
+
+// readNewLines reads whatever was appended to path since offset, sending
+// each line to out, and returns the new offset to resume from next tick.
+func readNewLines(path string, offset int64, out chan<- string, done <-chan struct{}) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return offset
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return offset
+	}
+	if info.Size() < offset {
+		// Log was truncated or rotated out from under us; restart from
+		// the top rather than seeking past the new content.
+		offset = 0
+	}
+	if info.Size() == offset {
+		return offset
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return offset
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		select {
+		case out <- scanner.Text():
+		case <-done:
+			return offset
+		}
+	}
+	return info.Size()
+}
@@ -0,0 +1,174 @@
+package actionsapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Server wires a Dispatcher up to the HTTP API. It binds to loopback by
+// default and requires a bearer token on every request; see EnsureToken.
+type Server struct {
+	dispatcher   Dispatcher
+	token        string
+	usageLogPath string
+	mux          *http.ServeMux
+}
+
+// NewServer returns a Server that serves dispatcher over HTTP, requiring
+// "Authorization: Bearer <token>" on every request and appending invoke
+// start/finish records to usageLogPath (the same usage.jsonl searchd
+// logs search queries to) so GET /events has something to stream.
+func NewServer(dispatcher Dispatcher, token, usageLogPath string) *Server {
+	s := &Server{dispatcher: dispatcher, token: token, usageLogPath: usageLogPath, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/actions", s.handleActions)
+	s.mux.HandleFunc("/actions/", s.handleActionPath)
+	s.mux.HandleFunc("/events", s.handleEvents)
+	return s
+}
+
+// ListenAndServe serves the API on addr (ordinarily a loopback address)
+// until it errors out.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.authMiddleware(s.mux))
+}
+
+// authMiddleware rejects any request without a matching bearer token.
+// There's no bypass for loopback callers: the token file is the one
+// access control this package has, so every request goes through it.
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleActions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.dispatcher.ListActions())
+}
+
+// handleActionPath dispatches everything under /actions/ to handleAction
+// (GET /actions/{id}) or handleInvoke (POST /actions/{id}/invoke), since
+// Go 1.21's http.ServeMux has no method- or wildcard-aware routing.
+func (s *Server) handleActionPath(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/actions/")
+	id, sub, hasSub := strings.Cut(rest, "/")
+	if id == "" {
+		http.NotFound(w, r)
+		return
+	}
+	switch {
+	case !hasSub:
+		s.handleAction(w, r, id)
+	case sub == "invoke":
+		s.handleInvoke(w, r, id)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) handleAction(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	for _, action := range s.dispatcher.ListActions() {
+		if action.ID == id {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(action)
+			return
+		}
+	}
+	http.NotFound(w, r)
+}
+
+// invokeRequest is POST /actions/{id}/invoke's optional body: Args
+// overrides the action's configured arguments, the same override
+// ExecuteWithArgs supports over D-Bus.
+type invokeRequest struct {
+	Args []string `json:"args,omitempty"`
+}
+
+func (s *Server) handleInvoke(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	defer r.Body.Close()
+	var req invokeRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	logUsageEvent(s.usageLogPath, "action_invoke_start", id, nil)
+	start := time.Now()
+	exitCode, stdout, stderr, err := s.dispatcher.Execute(id, req.Args)
+	fields := map[string]any{
+		"exit_code":  exitCode,
+		"latency_ms": time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	logUsageEvent(s.usageLogPath, "action_invoke_finish", id, fields)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"id":        id,
+		"exit_code": exitCode,
+		"stdout":    stdout,
+		"stderr":    stderr,
+		"error":     errString(err),
+	})
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+// handleEvents streams usageLogPath as server-sent events, one "data:"
+// line per JSON record appended after the client connects -- invocation
+// start/finish records from this server, and whatever else (e.g.
+// searchd's search_query/index_crawl events) shares the same file.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	lines := make(chan string, 16)
+	done := make(chan struct{})
+	defer close(done)
+	go tailUsageLog(s.usageLogPath, lines, done)
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			fmt.Fprintf(w, "data: %s\n\n", line)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package actionsapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnsureToken reads the bearer token at path, generating and persisting a
+// random one on first run. The file is written 0600, since anything with
+// read access to it can invoke launcher actions as this user.
+func EnsureToken(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return strings.TrimSpace(string(data)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("actionsapi: read token %s: %w", path, err)
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("actionsapi: generate token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return "", fmt.Errorf("actionsapi: create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(token+"\n"), 0600); err != nil {
+		return "", fmt.Errorf("actionsapi: write token %s: %w", path, err)
+	}
+	return token, nil
+}
@@ -0,0 +1,58 @@
+// Package blueprintstore abstracts where quantum-forge persists the
+// Palimpsest Blueprints it produces. cmd/quantum_forge used to read and
+// write .omarchy/palimpsest/blueprints/quantum-forge directly; that's now
+// one Store implementation (FilesystemStore) alongside an etcd3-backed one
+// that lets multiple hosts share a single lattice instead of each writing
+// to its own local directory.
+package blueprintstore
+
+import (
+	"context"
+	"time"
+)
+
+// Blueprint is a single Palimpsest Blueprint emitted by a quantum-forge
+// invocation.
+type Blueprint struct {
+	ID         string    `json:"id"`
+	Timestamp  time.Time `json:"timestamp"`
+	VBHCounter int       `json:"vbhCounter"`
+	VBHHash    string    `json:"vbhHash"`
+	Content    string    `json:"content"`
+	Metrics    Metrics   `json:"metrics"`
+	BuildID    string    `json:"buildId"`
+	OpenTasks  int       `json:"openTasks"`
+}
+
+// Metrics are the equilibrium metrics annotated onto a Blueprint.
+type Metrics struct {
+	LambdaEntropy    float64 `json:"lambdaEntropy"`
+	MDLDelta         int     `json:"mdlDelta"`
+	TraceSimilarity  float64 `json:"traceSimilarity"`
+	QuantumCoherence float64 `json:"quantumCoherence"`
+}
+
+// EventType distinguishes a BlueprintEvent's kind.
+type EventType string
+
+const (
+	EventPut    EventType = "put"
+	EventDelete EventType = "delete"
+)
+
+// BlueprintEvent is one change observed via Store.Watch.
+type BlueprintEvent struct {
+	Type      EventType
+	Blueprint Blueprint
+}
+
+// Store is how quantum-forge persists and discovers Blueprints. Save takes
+// bp by pointer because a CAS-backed implementation (EtcdStore) assigns
+// the final VBHCounter/ID itself — a caller's locally-incremented counter
+// can't be trusted once more than one host is writing to the same lattice.
+type Store interface {
+	Save(ctx context.Context, bp *Blueprint) error
+	List(ctx context.Context) ([]Blueprint, error)
+	Get(ctx context.Context, id string) (Blueprint, error)
+	Watch(ctx context.Context) (<-chan BlueprintEvent, error)
+}
@@ -0,0 +1,167 @@
+package blueprintstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/client/v3"
+)
+
+const etcdKeyPrefix = "/omarchy/palimpsest/blueprints/quantum-forge/"
+
+const etcdCounterKey = etcdKeyPrefix + "counter"
+
+// EtcdStore shares one quantum-forge lattice across every host in a
+// deployment instead of each host writing to its own .omarchy/ directory.
+// Every Blueprint is stored at etcdKeyPrefix+<vbhCounter>, with the
+// counter itself advanced under compare-and-swap so two concurrent
+// invocations on different hosts can't collide on the same counter value.
+type EtcdStore struct {
+	Client *clientv3.Client
+}
+
+// NewEtcdStore dials endpoints with a 5s connect timeout, matching the
+// default this repo already uses for external-process timeouts (see
+// internal/secrets's 30s sops timeout, cmd/omai's 2-minute completion
+// timeout — all proportional to how slow the operation normally is).
+func NewEtcdStore(endpoints []string) (*EtcdStore, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("blueprintstore: dial etcd: %w", err)
+	}
+	return &EtcdStore{Client: client}, nil
+}
+
+// Save assigns bp the next VBH counter value via a compare-and-swap retry
+// loop (read current counter, increment, Txn on mod_revision staying
+// unchanged) and writes the blueprint under its counter-derived key. The
+// retry loop means two hosts racing to save at once never observe the
+// same counter value twice.
+func (s *EtcdStore) Save(ctx context.Context, bp *Blueprint) error {
+	for {
+		getResp, err := s.Client.Get(ctx, etcdCounterKey)
+		if err != nil {
+			return fmt.Errorf("blueprintstore: read counter: %w", err)
+		}
+
+		var current int64
+		var prevRev int64
+		if len(getResp.Kvs) > 0 {
+			kv := getResp.Kvs[0]
+			current, err = strconv.ParseInt(string(kv.Value), 10, 64)
+			if err != nil {
+				return fmt.Errorf("blueprintstore: parse counter: %w", err)
+			}
+			prevRev = kv.ModRevision
+		}
+		next := current + 1
+
+		bp.VBHCounter = int(next)
+		bp.ID = fmt.Sprintf("quantum-forge-%d", next)
+		data, err := json.Marshal(bp)
+		if err != nil {
+			return fmt.Errorf("blueprintstore: marshal blueprint: %w", err)
+		}
+		key := fmt.Sprintf("%s%d", etcdKeyPrefix, next)
+
+		txn := s.Client.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(etcdCounterKey), "=", prevRev)).
+			Then(
+				clientv3.OpPut(etcdCounterKey, strconv.FormatInt(next, 10)),
+				clientv3.OpPut(key, string(data)),
+			)
+		resp, err := txn.Commit()
+		if err != nil {
+			return fmt.Errorf("blueprintstore: commit txn: %w", err)
+		}
+		if resp.Succeeded {
+			return nil
+		}
+		// Another host advanced the counter between our Get and Commit;
+		// retry with the new value.
+	}
+}
+
+func (s *EtcdStore) List(ctx context.Context) ([]Blueprint, error) {
+	resp, err := s.Client.Get(ctx, etcdKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("blueprintstore: list: %w", err)
+	}
+
+	var blueprints []Blueprint
+	for _, kv := range resp.Kvs {
+		if string(kv.Key) == etcdCounterKey {
+			continue
+		}
+		var bp Blueprint
+		if err := json.Unmarshal(kv.Value, &bp); err != nil {
+			return nil, fmt.Errorf("blueprintstore: parse %s: %w", kv.Key, err)
+		}
+		blueprints = append(blueprints, bp)
+	}
+	return blueprints, nil
+}
+
+func (s *EtcdStore) Get(ctx context.Context, id string) (Blueprint, error) {
+	counter := strings.TrimPrefix(id, "quantum-forge-")
+	resp, err := s.Client.Get(ctx, etcdKeyPrefix+counter)
+	if err != nil {
+		return Blueprint{}, fmt.Errorf("blueprintstore: get %s: %w", id, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return Blueprint{}, fmt.Errorf("blueprintstore: %s not found", id)
+	}
+	var bp Blueprint
+	if err := json.Unmarshal(resp.Kvs[0].Value, &bp); err != nil {
+		return Blueprint{}, fmt.Errorf("blueprintstore: parse %s: %w", id, err)
+	}
+	return bp, nil
+}
+
+// Watch subscribes to every blueprint put under etcdKeyPrefix from the
+// store's current revision onward, so monitor dashboards see new arrivals
+// in real time instead of polling.
+func (s *EtcdStore) Watch(ctx context.Context) (<-chan BlueprintEvent, error) {
+	getResp, err := s.Client.Get(ctx, etcdKeyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("blueprintstore: watch: read current revision: %w", err)
+	}
+	startRev := getResp.Header.Revision + 1
+
+	events := make(chan BlueprintEvent)
+	watchChan := s.Client.Watch(ctx, etcdKeyPrefix, clientv3.WithPrefix(), clientv3.WithRev(startRev))
+
+	go func() {
+		defer close(events)
+		for resp := range watchChan {
+			for _, ev := range resp.Events {
+				if string(ev.Kv.Key) == etcdCounterKey {
+					continue
+				}
+				switch ev.Type {
+				case clientv3.EventTypePut:
+					var bp Blueprint
+					if json.Unmarshal(ev.Kv.Value, &bp) != nil {
+						continue
+					}
+					events <- BlueprintEvent{Type: EventPut, Blueprint: bp}
+				case clientv3.EventTypeDelete:
+					var bp Blueprint
+					if ev.PrevKv != nil {
+						_ = json.Unmarshal(ev.PrevKv.Value, &bp)
+					}
+					events <- BlueprintEvent{Type: EventDelete, Blueprint: bp}
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,112 @@
+package blueprintstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FilesystemStore is the original quantum-forge behavior: each Blueprint
+// becomes a <dir>/<id>.json plus a human-readable <dir>/<id>.md, both
+// written straight to a local directory.
+type FilesystemStore struct {
+	Dir string
+}
+
+// NewFilesystemStore returns a Store rooted at dir, creating it on first
+// Save if necessary.
+func NewFilesystemStore(dir string) *FilesystemStore {
+	return &FilesystemStore{Dir: dir}
+}
+
+func (s *FilesystemStore) Save(ctx context.Context, bp *Blueprint) error {
+	if err := os.MkdirAll(s.Dir, 0o755); err != nil {
+		return fmt.Errorf("blueprintstore: create %s: %w", s.Dir, err)
+	}
+
+	data, err := json.MarshalIndent(bp, "", "  ")
+	if err != nil {
+		return fmt.Errorf("blueprintstore: marshal blueprint: %w", err)
+	}
+	jsonFile := filepath.Join(s.Dir, bp.ID+".json")
+	if err := os.WriteFile(jsonFile, data, 0o644); err != nil {
+		return fmt.Errorf("blueprintstore: write %s: %w", jsonFile, err)
+	}
+
+	mdFile := filepath.Join(s.Dir, bp.ID+".md")
+	if err := os.WriteFile(mdFile, []byte(renderMarkdown(*bp)), 0o644); err != nil {
+		return fmt.Errorf("blueprintstore: write %s: %w", mdFile, err)
+	}
+
+	return nil
+}
+
+func (s *FilesystemStore) List(ctx context.Context) ([]Blueprint, error) {
+	if _, err := os.Stat(s.Dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.Dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("blueprintstore: glob %s: %w", s.Dir, err)
+	}
+
+	var blueprints []Blueprint
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("blueprintstore: read %s: %w", file, err)
+		}
+		var bp Blueprint
+		if err := json.Unmarshal(data, &bp); err != nil {
+			return nil, fmt.Errorf("blueprintstore: parse %s: %w", file, err)
+		}
+		blueprints = append(blueprints, bp)
+	}
+	return blueprints, nil
+}
+
+func (s *FilesystemStore) Get(ctx context.Context, id string) (Blueprint, error) {
+	data, err := os.ReadFile(filepath.Join(s.Dir, id+".json"))
+	if err != nil {
+		return Blueprint{}, fmt.Errorf("blueprintstore: read %s: %w", id, err)
+	}
+	var bp Blueprint
+	if err := json.Unmarshal(data, &bp); err != nil {
+		return Blueprint{}, fmt.Errorf("blueprintstore: parse %s: %w", id, err)
+	}
+	return bp, nil
+}
+
+// Watch is unsupported on FilesystemStore: a single local directory has no
+// host to subscribe across, so callers needing live updates should use
+// EtcdStore instead.
+func (s *FilesystemStore) Watch(ctx context.Context) (<-chan BlueprintEvent, error) {
+	return nil, fmt.Errorf("blueprintstore: FilesystemStore does not support Watch; use the etcd backend")
+}
+
+func renderMarkdown(bp Blueprint) string {
+	return fmt.Sprintf(`# Quantum-Forge Blueprint: %s
+
+**Generated:** %s
+**VBH Counter:** %d
+**Build ID:** %s
+**Open Tasks:** %d
+
+## Metrics
+
+- **Lambda Entropy:** %.3f
+- **MDL Delta:** %d bytes
+- **Trace Similarity:** %.3f
+- **Quantum Coherence:** %.3f
+
+## Content
+
+%s
+`, bp.ID, bp.Timestamp.Format(time.RFC3339), bp.VBHCounter,
+		bp.BuildID, bp.OpenTasks, bp.Metrics.LambdaEntropy,
+		bp.Metrics.MDLDelta, bp.Metrics.TraceSimilarity, bp.Metrics.QuantumCoherence, bp.Content)
+}
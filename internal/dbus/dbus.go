@@ -0,0 +1,146 @@
+// Package dbus exposes the launcher's actions on the user's D-Bus session
+// bus as org.omarchy.Launcher1, so rofi/wofi, waybar modules, AI
+// subagents and other external tools can drive the launcher without
+// shelling out to the CLI. It only translates LauncherAction into
+// introspectable properties and method calls; the actual execution
+// (including sandbox enforcement) stays with whatever Dispatcher the
+// caller wires in, the same one the CLI's "run <id>" path uses.
+package dbus
+
+import (
+	"fmt"
+
+	godbus "github.com/godbus/dbus/v5"
+	"github.com/godbus/dbus/v5/introspect"
+)
+
+const (
+	busName       = "org.omarchy.Launcher1"
+	objectPath    = "/org/omarchy/Launcher1"
+	interfaceName = "org.omarchy.Launcher1"
+)
+
+// ActionInfo is the subset of LauncherAction exposed over the bus, in the
+// field order ListActions returns it: id, name, description, icon,
+// category (D-Bus signature "a(sssss)").
+type ActionInfo struct {
+	ID          string
+	Name        string
+	Description string
+	Icon        string
+	Category    string
+}
+
+// Dispatcher is what a Service calls into to list and run actions. The
+// caller's implementation is responsible for sandbox enforcement: Service
+// itself has no opinion on how Execute runs a command.
+type Dispatcher interface {
+	ListActions() []ActionInfo
+	Execute(id string, args []string) (exitCode int, stdoutTail string, err error)
+}
+
+// Service owns the session-bus connection and the exported
+// org.omarchy.Launcher1 object.
+type Service struct {
+	conn   *godbus.Conn
+	object *launcherObject
+}
+
+// NewService connects to the session bus, requests busName, and exports
+// the launcher object and its introspection data. The service does not
+// start serving until Run is called.
+func NewService(dispatcher Dispatcher) (*Service, error) {
+	conn, err := godbus.ConnectSessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("dbus: connect session bus: %w", err)
+	}
+
+	reply, err := conn.RequestName(busName, godbus.NameFlagDoNotQueue)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("dbus: request name %s: %w", busName, err)
+	}
+	if reply != godbus.RequestNameReplyPrimaryOwner {
+		conn.Close()
+		return nil, fmt.Errorf("dbus: %s is already owned on this session bus", busName)
+	}
+
+	object := &launcherObject{conn: conn, dispatcher: dispatcher}
+	if err := conn.Export(object, objectPath, interfaceName); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("dbus: export %s: %w", interfaceName, err)
+	}
+	if err := conn.Export(introspect.Introspectable(introspectXML), objectPath, "org.freedesktop.DBus.Introspectable"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("dbus: export introspection: %w", err)
+	}
+
+	return &Service{conn: conn, object: object}, nil
+}
+
+// Close releases busName and closes the bus connection.
+func (s *Service) Close() error {
+	s.conn.ReleaseName(busName)
+	return s.conn.Close()
+}
+
+// launcherObject is the exported org.omarchy.Launcher1 object. Its
+// exported methods follow godbus's convention of a trailing
+// *godbus.Error return value.
+type launcherObject struct {
+	conn       *godbus.Conn
+	dispatcher Dispatcher
+}
+
+// ListActions returns every action as (id, name, description, icon,
+// category) tuples -- D-Bus signature "a(sssss)".
+func (o *launcherObject) ListActions() ([]ActionInfo, *godbus.Error) {
+	return o.dispatcher.ListActions(), nil
+}
+
+// Execute runs action id with no extra arguments and emits
+// ActionCompleted once it finishes.
+func (o *launcherObject) Execute(id string) (bool, *godbus.Error) {
+	return o.ExecuteWithArgs(id, nil)
+}
+
+// ExecuteWithArgs runs action id with args overriding its configured
+// Args, gated through the same sandbox profile enforcement the CLI's
+// "run <id>" path uses, and emits ActionCompleted with the exit status
+// and a tail of captured stdout.
+func (o *launcherObject) ExecuteWithArgs(id string, args []string) (bool, *godbus.Error) {
+	exitCode, stdoutTail, err := o.dispatcher.Execute(id, args)
+	o.conn.Emit(objectPath, interfaceName+".ActionCompleted", id, int32(exitCode), stdoutTail)
+	if err != nil {
+		return false, godbus.MakeFailedError(err)
+	}
+	return true, nil
+}
+
+// introspectXML describes the exported interface for
+// org.freedesktop.DBus.Introspectable callers (rofi/wofi plugins,
+// d-feet, busctl introspect).
+const introspectXML = `
+<node>
+	<interface name="` + interfaceName + `">
+		<method name="ListActions">
+			<arg name="actions" type="a(sssss)" direction="out"/>
+		</method>
+		<method name="Execute">
+			<arg name="id" type="s" direction="in"/>
+			<arg name="ok" type="b" direction="out"/>
+		</method>
+		<method name="ExecuteWithArgs">
+			<arg name="id" type="s" direction="in"/>
+			<arg name="args" type="as" direction="in"/>
+			<arg name="ok" type="b" direction="out"/>
+		</method>
+		<signal name="ActionCompleted">
+			<arg name="id" type="s"/>
+			<arg name="exitCode" type="i"/>
+			<arg name="stdoutTail" type="s"/>
+		</signal>
+	</interface>
+` + introspect.IntrospectDataString + `
+</node>
+`
@@ -0,0 +1,205 @@
+package hotkey
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// inputEvent mirrors Linux's struct input_event on 64-bit kernels: a
+// 16-byte timeval followed by a 16-bit type, a 16-bit code and a 32-bit
+// value. We only care about type/code/value, so the timeval is read and
+// discarded.
+type inputEvent struct {
+	Sec, Usec uint64
+	Type      uint16
+	Code      uint16
+	Value     int32
+}
+
+const evKey = 0x01
+
+// modifierCodes maps evdev key codes to the modifier name used in our
+// "Super+Shift+S"-style hotkey strings.
+var modifierCodes = map[uint16]string{
+	125: "SUPER", 126: "SUPER", // KEY_LEFTMETA, KEY_RIGHTMETA
+	42: "SHIFT", 54: "SHIFT", // KEY_LEFTSHIFT, KEY_RIGHTSHIFT
+	29: "CTRL", 97: "CTRL", // KEY_LEFTCTRL, KEY_RIGHTCTRL
+	56: "ALT", 100: "ALT", // KEY_LEFTALT, KEY_RIGHTALT
+}
+
+// keyCodes maps the evdev codes for letters, digits and a few named keys
+// to the spelling used in hotkey strings. Letters follow the standard
+// evdev ordering (KEY_A=30 ... KEY_Z=44, out of qwerty order).
+var keyCodes = map[uint16]string{
+	30: "A", 48: "B", 46: "C", 32: "D", 18: "E", 33: "F", 34: "G", 35: "H",
+	23: "I", 36: "J", 37: "K", 38: "L", 50: "M", 49: "N", 24: "O", 25: "P",
+	16: "Q", 19: "R", 31: "S", 20: "T", 22: "U", 47: "V", 17: "W", 45: "X",
+	21: "Y", 44: "Z",
+	2: "1", 3: "2", 4: "3", 5: "4", 6: "5", 7: "6", 8: "7", 9: "8", 10: "9", 11: "0",
+	28: "Enter", 57: "Space",
+}
+
+// EvdevBackend grabs /dev/input/event* directly, for sessions with no
+// compositor-level bind mechanism (i.e. not Hyprland). Unlike the
+// Hyprland backend it detects combos itself and calls dispatch in-process.
+type EvdevBackend struct {
+	mu      sync.Mutex
+	combos  map[string]string // "SUPER+SHIFT+S" -> action ID
+	stop    chan struct{}
+	stopped bool
+}
+
+// NewEvdevBackend returns an idle backend; call Register to start grabbing.
+func NewEvdevBackend() *EvdevBackend {
+	return &EvdevBackend{}
+}
+
+func (b *EvdevBackend) Name() string { return "evdev" }
+
+// Register replaces the active combo set and, on first call, opens every
+// /dev/input/event* device and starts one reader goroutine per device.
+func (b *EvdevBackend) Register(bindings []Binding, dispatch func(actionID string)) error {
+	combos := make(map[string]string, len(bindings))
+	for _, binding := range bindings {
+		combo, err := normalizeCombo(binding.Hotkey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  skipping hotkey for %s: %v\n", binding.ActionID, err)
+			continue
+		}
+		combos[combo] = binding.ActionID
+	}
+
+	b.mu.Lock()
+	alreadyRunning := b.stop != nil
+	b.combos = combos
+	if !alreadyRunning {
+		b.stop = make(chan struct{})
+	}
+	b.mu.Unlock()
+
+	if alreadyRunning {
+		return nil
+	}
+
+	devices, err := filepath.Glob("/dev/input/event*")
+	if err != nil {
+		return fmt.Errorf("hotkey: glob /dev/input: %w", err)
+	}
+	if len(devices) == 0 {
+		return fmt.Errorf("hotkey: no /dev/input/event* devices found (need to run as a user with input-group access)")
+	}
+	for _, dev := range devices {
+		go b.watch(dev, dispatch)
+	}
+	return nil
+}
+
+// Deregister stops all reader goroutines.
+func (b *EvdevBackend) Deregister() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.stop != nil && !b.stopped {
+		close(b.stop)
+		b.stopped = true
+	}
+	return nil
+}
+
+func (b *EvdevBackend) watch(path string, dispatch func(actionID string)) {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  hotkey: open %s: %v\n", path, err)
+		return
+	}
+	defer f.Close()
+
+	pressed := make(map[uint16]bool)
+	buf := make([]byte, 24)
+	for {
+		select {
+		case <-b.stop:
+			return
+		default:
+		}
+
+		n, err := f.Read(buf)
+		if err != nil || n < 24 {
+			return
+		}
+		var ev inputEvent
+		ev.Sec = binary.LittleEndian.Uint64(buf[0:8])
+		ev.Usec = binary.LittleEndian.Uint64(buf[8:16])
+		ev.Type = binary.LittleEndian.Uint16(buf[16:18])
+		ev.Code = binary.LittleEndian.Uint16(buf[18:20])
+		ev.Value = int32(binary.LittleEndian.Uint32(buf[20:24]))
+
+		if ev.Type != evKey {
+			continue
+		}
+		switch ev.Value {
+		case 0:
+			delete(pressed, ev.Code)
+		case 1:
+			pressed[ev.Code] = true
+			b.matchCombo(pressed, dispatch)
+		}
+	}
+}
+
+func (b *EvdevBackend) matchCombo(pressed map[uint16]bool, dispatch func(actionID string)) {
+	var mods []string
+	var key string
+	for code := range pressed {
+		if mod, ok := modifierCodes[code]; ok {
+			mods = append(mods, mod)
+			continue
+		}
+		if name, ok := keyCodes[code]; ok {
+			key = name
+		}
+	}
+	if key == "" {
+		return
+	}
+	combo := canonicalCombo(mods, key)
+
+	b.mu.Lock()
+	actionID, ok := b.combos[combo]
+	b.mu.Unlock()
+	if ok {
+		dispatch(actionID)
+	}
+}
+
+// normalizeCombo parses a "Super+Shift+S"-style hotkey into the canonical
+// "SUPER+SHIFT+S" form matchCombo compares against.
+func normalizeCombo(hotkey string) (string, error) {
+	parts := strings.Split(hotkey, "+")
+	if len(parts) == 0 || parts[len(parts)-1] == "" {
+		return "", fmt.Errorf("empty hotkey")
+	}
+	key := strings.TrimSpace(parts[len(parts)-1])
+	if key != "Enter" && key != "Space" {
+		key = strings.ToUpper(key)
+	}
+	var mods []string
+	for _, part := range parts[:len(parts)-1] {
+		mods = append(mods, strings.ToUpper(strings.TrimSpace(part)))
+	}
+	return canonicalCombo(mods, key), nil
+}
+
+func canonicalCombo(mods []string, key string) string {
+	order := map[string]int{"SUPER": 0, "CTRL": 1, "ALT": 2, "SHIFT": 3}
+	sorted := append([]string(nil), mods...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && order[sorted[j-1]] > order[sorted[j]]; j-- {
+			sorted[j-1], sorted[j] = sorted[j], sorted[j-1]
+		}
+	}
+	return strings.Join(append(sorted, key), "+")
+}
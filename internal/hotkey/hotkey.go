@@ -0,0 +1,55 @@
+// Package hotkey turns a LauncherAction's Hotkey field into a real global
+// key binding instead of a tty prompt, via one of two backends: Hyprland's
+// own bind/exec mechanism, or a raw evdev grab for sessions without it.
+package hotkey
+
+import (
+	"fmt"
+	"os"
+)
+
+// Binding is one hotkey-to-action mapping a Backend is asked to register.
+type Binding struct {
+	ActionID string
+	Hotkey   string
+}
+
+// Backend is one way of turning Bindings into live global key presses.
+// Register should be idempotent: calling it again replaces any previously
+// registered set. dispatch is invoked with the matching ActionID whenever
+// a backend detects the combo itself (currently only the evdev backend,
+// since the Hyprland backend delegates to an external `omarchy-launcher
+// run <id>` exec instead).
+type Backend interface {
+	// Name is the backend's identifier, e.g. "hyprland", "evdev".
+	Name() string
+	Register(bindings []Binding, dispatch func(actionID string)) error
+	Deregister() error
+}
+
+// DetectBackend picks the Hyprland backend when running inside a Hyprland
+// session (HYPRLAND_INSTANCE_SIGNATURE is set), falling back to the evdev
+// backend otherwise.
+func DetectBackend(homeDir string) (Backend, error) {
+	if os.Getenv("HYPRLAND_INSTANCE_SIGNATURE") != "" {
+		return NewHyprlandBackend(homeDir), nil
+	}
+	return NewEvdevBackend(), nil
+}
+
+// execName is the command Hyprland binds exec into, and what the CLI's
+// "run <id>" subcommand forwards to the daemon's socket. It is a var (not
+// a const) so callers embedding this package under a different binary
+// name can override it.
+var execName = "omarchy-launcher"
+
+// SetExecName overrides the command name written into generated binds.
+func SetExecName(name string) {
+	if name != "" {
+		execName = name
+	}
+}
+
+func runCommand(actionID string) string {
+	return fmt.Sprintf("%s run %s", execName, actionID)
+}
@@ -0,0 +1,132 @@
+package hotkey
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// hyprlandHeader marks the generated file so a stale one from a previous
+// run is recognizable (and safe to overwrite) rather than hand-edited.
+const hyprlandHeader = "# Generated by omarchy-launcher hotkey daemon. Do not edit by hand.\n"
+
+// HyprlandBackend registers hotkeys by writing `bind = MODS, KEY, exec, ...`
+// lines into a dedicated config file sourced from hyprland.conf, then
+// reloading Hyprland so the binds take effect immediately.
+type HyprlandBackend struct {
+	confPath         string
+	hyprlandConfPath string
+}
+
+// NewHyprlandBackend returns a backend writing binds to
+// ~/.config/hypr/omarchy-launcher.conf.
+func NewHyprlandBackend(homeDir string) *HyprlandBackend {
+	return &HyprlandBackend{
+		confPath:         filepath.Join(homeDir, ".config", "hypr", "omarchy-launcher.conf"),
+		hyprlandConfPath: filepath.Join(homeDir, ".config", "hypr", "hyprland.conf"),
+	}
+}
+
+func (b *HyprlandBackend) Name() string { return "hyprland" }
+
+// Register writes one `bind` line per binding whose Hotkey parses cleanly,
+// then reloads Hyprland. dispatch is unused: Hyprland itself detects the
+// key combo and execs `omarchy-launcher run <id>`, which reaches the
+// daemon through its Unix socket instead of this process directly.
+func (b *HyprlandBackend) Register(bindings []Binding, dispatch func(actionID string)) error {
+	var sb strings.Builder
+	sb.WriteString(hyprlandHeader)
+	for _, binding := range bindings {
+		mods, key, err := parseHotkey(binding.Hotkey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  skipping hotkey for %s: %v\n", binding.ActionID, err)
+			continue
+		}
+		fmt.Fprintf(&sb, "bind = %s, %s, exec, %s\n", mods, key, runCommand(binding.ActionID))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(b.confPath), 0755); err != nil {
+		return fmt.Errorf("hotkey: create %s: %w", filepath.Dir(b.confPath), err)
+	}
+	if err := os.WriteFile(b.confPath, []byte(sb.String()), 0644); err != nil {
+		return fmt.Errorf("hotkey: write %s: %w", b.confPath, err)
+	}
+	if err := b.ensureSourced(); err != nil {
+		return err
+	}
+	return b.reload()
+}
+
+// ensureSourced appends a `source = <confPath>` line to hyprland.conf if one
+// isn't already there. Hyprland never re-reads a file that isn't sourced, so
+// without this `hyprctl reload` reports success while the binds never take
+// effect. Idempotent: safe to call on every Register.
+func (b *HyprlandBackend) ensureSourced() error {
+	sourceLine := fmt.Sprintf("source = %s", b.confPath)
+
+	existing, err := os.ReadFile(b.hyprlandConfPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("hotkey: read %s: %w", b.hyprlandConfPath, err)
+	}
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == sourceLine {
+			return nil
+		}
+	}
+
+	f, err := os.OpenFile(b.hyprlandConfPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("hotkey: open %s: %w", b.hyprlandConfPath, err)
+	}
+	defer f.Close()
+	if _, err := fmt.Fprintf(f, "\n%s\n", sourceLine); err != nil {
+		return fmt.Errorf("hotkey: append source line to %s: %w", b.hyprlandConfPath, err)
+	}
+	return nil
+}
+
+// Deregister empties the generated config file and reloads, removing the
+// binds without touching the rest of hyprland.conf.
+func (b *HyprlandBackend) Deregister() error {
+	if err := os.WriteFile(b.confPath, []byte(hyprlandHeader), 0644); err != nil {
+		return fmt.Errorf("hotkey: clear %s: %w", b.confPath, err)
+	}
+	return b.reload()
+}
+
+func (b *HyprlandBackend) reload() error {
+	cmd := exec.Command("hyprctl", "reload")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// parseHotkey turns "Super+Shift+S" into Hyprland's "SUPER SHIFT" / "S"
+// bind syntax.
+func parseHotkey(hotkey string) (mods string, key string, err error) {
+	parts := strings.Split(hotkey, "+")
+	if len(parts) == 0 || parts[len(parts)-1] == "" {
+		return "", "", fmt.Errorf("empty hotkey")
+	}
+	key = hyprlandKeyName(strings.TrimSpace(parts[len(parts)-1]))
+	modParts := make([]string, 0, len(parts)-1)
+	for _, part := range parts[:len(parts)-1] {
+		modParts = append(modParts, strings.ToUpper(strings.TrimSpace(part)))
+	}
+	return strings.Join(modParts, " "), key, nil
+}
+
+// hyprlandKeyName maps our Enter/Space spellings to Hyprland's keysym
+// names; everything else (letters, digits) passes through unchanged.
+func hyprlandKeyName(key string) string {
+	switch strings.ToLower(key) {
+	case "enter":
+		return "Return"
+	case "space":
+		return "space"
+	default:
+		return key
+	}
+}
@@ -0,0 +1,138 @@
+// Package hyprland is a minimal client for Hyprland's IPC sockets: the
+// command socket (.socket.sock) for one-shot dispatch/keyword commands,
+// and the event socket (.socket2.sock) for the push stream of
+// workspace/window/monitor changes. It exists so omarchy-launcher.go can
+// register its own hotkey binds and react to focus changes directly,
+// instead of shelling out to hyprctl for every call.
+package hyprland
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Client talks to one Hyprland instance's IPC sockets.
+type Client struct {
+	cmdSocket   string
+	eventSocket string
+}
+
+// NewClient locates the IPC sockets for the running Hyprland instance via
+// $XDG_RUNTIME_DIR/hypr/$HYPRLAND_INSTANCE_SIGNATURE/.
+func NewClient() (*Client, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	signature := os.Getenv("HYPRLAND_INSTANCE_SIGNATURE")
+	if runtimeDir == "" || signature == "" {
+		return nil, fmt.Errorf("hyprland: not running inside a Hyprland session (XDG_RUNTIME_DIR/HYPRLAND_INSTANCE_SIGNATURE unset)")
+	}
+	dir := filepath.Join(runtimeDir, "hypr", signature)
+	return &Client{
+		cmdSocket:   filepath.Join(dir, ".socket.sock"),
+		eventSocket: filepath.Join(dir, ".socket2.sock"),
+	}, nil
+}
+
+// Dispatch sends a single command to the command socket and returns its
+// response, e.g. Dispatch("keyword bind SUPER,Return,exec,alacritty") or
+// Dispatch("dispatch exec alacritty").
+func (c *Client) Dispatch(command string) (string, error) {
+	conn, err := net.Dial("unix", c.cmdSocket)
+	if err != nil {
+		return "", fmt.Errorf("hyprland: dial %s: %w", c.cmdSocket, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(command)); err != nil {
+		return "", fmt.Errorf("hyprland: write command: %w", err)
+	}
+	var sb strings.Builder
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			sb.Write(buf[:n])
+		}
+		if err != nil {
+			break
+		}
+	}
+	return sb.String(), nil
+}
+
+// Bind registers a runtime keybind via `keyword bind`, so omarchy-launcher
+// starts reacting to Hotkey immediately, without editing hyprland.conf.
+func (c *Client) Bind(mods, key, dispatcher, params string) error {
+	command := fmt.Sprintf("keyword bind %s,%s,%s,%s", mods, key, dispatcher, params)
+	_, err := c.Dispatch(command)
+	return err
+}
+
+// Unbind removes a previously registered bind, for clean shutdown.
+func (c *Client) Unbind(mods, key string) error {
+	command := fmt.Sprintf("keyword unbind %s,%s", mods, key)
+	_, err := c.Dispatch(command)
+	return err
+}
+
+// Event is one line off the event socket, split at "TYPE>>DATA" --
+// e.g. {Kind: "workspace", Data: "2"} or
+// {Kind: "activewindow", Data: "alacritty,~/projects"}.
+type Event struct {
+	Kind string
+	Data string
+}
+
+// Subscribe connects to the event socket and streams parsed Events until
+// ctx-like done channel closes or the connection drops. If the socket
+// isn't present yet (e.g. the launcher started before Hyprland finished
+// initializing), it retries on retryInterval until it connects or done
+// fires.
+func (c *Client) Subscribe(done <-chan struct{}, retryInterval time.Duration) (<-chan Event, error) {
+	events := make(chan Event)
+
+	var conn net.Conn
+	for {
+		var err error
+		conn, err = net.Dial("unix", c.eventSocket)
+		if err == nil {
+			break
+		}
+		select {
+		case <-done:
+			close(events)
+			return events, nil
+		case <-time.After(retryInterval):
+		}
+	}
+
+	go func() {
+		defer close(events)
+		defer conn.Close()
+
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			line := scanner.Text()
+			kind, data, ok := strings.Cut(line, ">>")
+			if !ok {
+				continue
+			}
+			select {
+			case events <- Event{Kind: kind, Data: data}:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -0,0 +1,188 @@
+// Package launcherconfig lets the launcher's built-in action list be
+// overridden declaratively, the way NixOS modules layer option overrides
+// rather than editing a generator's source. Each file under
+// actions.d/*.toml contributes a Config that Merge layers over the
+// defaults, in directory order, last write wins per field.
+package launcherconfig
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/sandbox"
+)
+
+// CurrentSchemaVersion is bumped whenever a breaking change to the
+// on-disk shape below requires Load to migrate older files forward.
+const CurrentSchemaVersion = 1
+
+// Config is one actions.d/*.toml file's contents: a schema version plus
+// the per-action overrides and enable/disable flags it contributes.
+type Config struct {
+	Version int                       `toml:"version" validate:"required,min=1"`
+	Actions map[string]ActionOverride `toml:"actions" validate:"dive"`
+}
+
+// ActionOverride carries the subset of LauncherAction a config file may
+// override. Pointer/nil fields mean "leave the underlying value alone",
+// so a file that only sets Hotkey doesn't clobber Command.
+type ActionOverride struct {
+	Command  *string                 `toml:"command,omitempty"`
+	Args     []string                `toml:"args,omitempty"`
+	Hotkey   *string                 `toml:"hotkey,omitempty"`
+	Category *string                 `toml:"category,omitempty"`
+	Disabled *bool                   `toml:"disabled,omitempty"`
+	Sandbox  *sandbox.SandboxProfile `toml:"sandbox,omitempty"`
+}
+
+var (
+	validatorOnce sync.Once
+	validatorInst *validator.Validate
+)
+
+func instance() *validator.Validate {
+	validatorOnce.Do(func() { validatorInst = validator.New() })
+	return validatorInst
+}
+
+// Validate checks c against the `validate` struct tags above, returning
+// one message per failing field rather than bailing out on the first.
+func (c Config) Validate() []string {
+	err := instance().Struct(c)
+	if err == nil {
+		return nil
+	}
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+	var messages []string
+	for _, fe := range verrs {
+		messages = append(messages, fmt.Sprintf("%s failed the %q rule (got %v)", fe.Namespace(), fe.Tag(), fe.Value()))
+	}
+	return messages
+}
+
+// Load reads every *.toml file in dir in filename order and merges them
+// into a single effective Config via Merge. A missing dir is not an
+// error: it just means no overrides are configured.
+func Load(dir string) (Config, error) {
+	effective := Config{Version: CurrentSchemaVersion, Actions: map[string]ActionOverride{}}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return effective, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("launcherconfig: read %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".toml") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("launcherconfig: read %s: %w", path, err)
+		}
+		var layer Config
+		if err := toml.Unmarshal(data, &layer); err != nil {
+			return Config{}, fmt.Errorf("launcherconfig: parse %s: %w", path, err)
+		}
+		layer = migrate(layer)
+		if msgs := layer.Validate(); len(msgs) > 0 {
+			return Config{}, fmt.Errorf("launcherconfig: %s is invalid: %s", path, strings.Join(msgs, "; "))
+		}
+		effective = effective.Merge(layer)
+	}
+
+	return effective, nil
+}
+
+// Merge layers other's overrides on top of c, one action ID at a time, so
+// a later file can override a single field (e.g. Hotkey) of an action an
+// earlier file already touched without reverting its other overrides.
+func (c Config) Merge(other Config) Config {
+	merged := Config{Version: other.Version, Actions: make(map[string]ActionOverride, len(c.Actions))}
+	for id, override := range c.Actions {
+		merged.Actions[id] = override
+	}
+	for id, override := range other.Actions {
+		existing := merged.Actions[id]
+		merged.Actions[id] = existing.mergeOverride(override)
+	}
+	return merged
+}
+
+func (o ActionOverride) mergeOverride(other ActionOverride) ActionOverride {
+	merged := o
+	if other.Command != nil {
+		merged.Command = other.Command
+	}
+	if other.Args != nil {
+		merged.Args = other.Args
+	}
+	if other.Hotkey != nil {
+		merged.Hotkey = other.Hotkey
+	}
+	if other.Category != nil {
+		merged.Category = other.Category
+	}
+	if other.Disabled != nil {
+		merged.Disabled = other.Disabled
+	}
+	if other.Sandbox != nil {
+		merged.Sandbox = other.Sandbox
+	}
+	return merged
+}
+
+// Save writes the effective config to path as TOML, for operators who
+// want to snapshot the merged result of a whole actions.d/ directory into
+// one file.
+func (c Config) Save(path string) error {
+	data, err := toml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("launcherconfig: marshal: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("launcherconfig: write %s: %w", path, err)
+	}
+	return nil
+}
+
+// Dump writes c as TOML to w, for a --print-config flag that shows the
+// effective merged config without writing it anywhere.
+func (c Config) Dump(w io.Writer) error {
+	data, err := toml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("launcherconfig: marshal: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// migrate upgrades a Config parsed from an older file to
+// CurrentSchemaVersion. There is only one version today, so this is a
+// no-op beyond stamping the version field; it exists so a future bump
+// has one place to add the v1->v2 (etc.) translation.
+func migrate(c Config) Config {
+	if c.Version == 0 {
+		c.Version = CurrentSchemaVersion
+	}
+	return c
+}
@@ -0,0 +1,211 @@
+package omai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// AnthropicConfig configures a provider for the Anthropic Messages API.
+type AnthropicConfig struct {
+	Endpoint   string
+	APIKey     string
+	Version    string
+	HTTPClient *http.Client
+}
+
+type anthropicProvider struct {
+	cfg AnthropicConfig
+}
+
+// DefaultAnthropicEndpoint is the Anthropic Messages API URL.
+const DefaultAnthropicEndpoint = "https://api.anthropic.com/v1/messages"
+
+// NewAnthropic builds a Provider for api.anthropic.com (or a compatible
+// proxy, via cfg.Endpoint).
+func NewAnthropic(cfg AnthropicConfig) Provider {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultAnthropicEndpoint
+	}
+	if cfg.Version == "" {
+		cfg.Version = "2023-06-01"
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &anthropicProvider{cfg: cfg}
+}
+
+func (p *anthropicProvider) Name() string { return "anthropic" }
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	MaxTokens int                `json:"max_tokens"`
+	Stream    bool               `json:"stream"`
+}
+
+type anthropicContentBlock struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type anthropicResponse struct {
+	Content    []anthropicContentBlock `json:"content"`
+	Model      string                  `json:"model"`
+	StopReason string                  `json:"stop_reason"`
+}
+
+// toAnthropic splits the system message out (Anthropic takes it as a
+// top-level field) from the user/assistant turns.
+func toAnthropic(req Request) (system string, turns []anthropicMessage) {
+	for _, m := range req.Messages {
+		if m.Role == RoleSystem {
+			system = m.Content
+			continue
+		}
+		turns = append(turns, anthropicMessage{Role: string(m.Role), Content: m.Content})
+	}
+	return system, turns
+}
+
+func (p *anthropicProvider) newRequest(ctx context.Context, req Request) (*http.Request, error) {
+	system, turns := toAnthropic(req)
+	body, err := json.Marshal(anthropicRequest{
+		Model:     req.Model,
+		System:    system,
+		Messages:  turns,
+		MaxTokens: 4096,
+		Stream:    req.Stream,
+	})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", p.cfg.APIKey)
+	httpReq.Header.Set("anthropic-version", p.cfg.Version)
+	return httpReq, nil
+}
+
+func (p *anthropicProvider) classify(resp *http.Response, body []byte) error {
+	kind := ErrKindUnknownKind
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized:
+		kind = ErrKindAuth
+	case resp.StatusCode == http.StatusTooManyRequests:
+		kind = ErrKindRateLimit
+	case resp.StatusCode >= 500:
+		kind = ErrKindServer
+	case resp.StatusCode >= 400:
+		kind = ErrKindBadRequest
+	}
+	return &Error{Kind: kind, Provider: "anthropic", StatusCode: resp.StatusCode, Message: string(body)}
+}
+
+func (p *anthropicProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	req.Stream = false
+	httpReq, err := p.newRequest(ctx, req)
+	if err != nil {
+		return Response{}, &Error{Kind: ErrKindConnection, Provider: "anthropic", Message: err.Error(), Err: err}
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return Response{}, &Error{Kind: ErrKindConnection, Provider: "anthropic", Message: err.Error(), Err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(resp.Body)
+		return Response{}, p.classify(resp, buf.Bytes())
+	}
+
+	var out anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Response{}, &Error{Kind: ErrKindUnknownKind, Provider: "anthropic", Message: err.Error(), Err: err}
+	}
+	var text string
+	for _, block := range out.Content {
+		if block.Type == "text" {
+			text += block.Text
+		}
+	}
+	return Response{Content: text, Model: out.Model, FinishReason: out.StopReason}, nil
+}
+
+// anthropicStreamEvent covers the subset of the Messages streaming
+// protocol we need: content_block_delta carries text, message_stop ends it.
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Text       string `json:"text"`
+		StopReason string `json:"stop_reason"`
+	} `json:"delta"`
+}
+
+func (p *anthropicProvider) Stream(ctx context.Context, req Request) (<-chan Chunk, <-chan error) {
+	chunks := make(chan Chunk)
+	errs := make(chan error, 1)
+
+	req.Stream = true
+	httpReq, err := p.newRequest(ctx, req)
+	if err != nil {
+		errs <- &Error{Kind: ErrKindConnection, Provider: "anthropic", Message: err.Error(), Err: err}
+		close(chunks)
+		close(errs)
+		return chunks, errs
+	}
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		resp, err := p.cfg.HTTPClient.Do(httpReq)
+		if err != nil {
+			errs <- &Error{Kind: ErrKindConnection, Provider: "anthropic", Message: err.Error(), Err: err}
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(resp.Body)
+			errs <- p.classify(resp, buf.Bytes())
+			return
+		}
+
+		for ev := range scanSSE(resp.Body) {
+			var parsed anthropicStreamEvent
+			if err := json.Unmarshal([]byte(ev.data), &parsed); err != nil {
+				continue
+			}
+			switch parsed.Type {
+			case "content_block_delta":
+				if parsed.Delta.Text != "" {
+					chunks <- Chunk{Delta: parsed.Delta.Text}
+				}
+			case "message_delta":
+				if parsed.Delta.StopReason != "" {
+					chunks <- Chunk{FinishReason: parsed.Delta.StopReason, Final: true}
+					return
+				}
+			case "message_stop":
+				return
+			}
+		}
+	}()
+
+	return chunks, errs
+}
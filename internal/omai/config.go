@@ -0,0 +1,68 @@
+package omai
+
+import (
+	"fmt"
+	"os"
+)
+
+// Config selects and parameterizes a Provider. It is populated from the
+// .env file written by the generator (OR_MODEL, OPENROUTER_API_KEY, ...)
+// with OMAI_PROVIDER/OMAI_MODEL environment overrides for switching
+// backends without regenerating the environment.
+type Config struct {
+	Provider string // "openrouter" (default), "ollama", "anthropic", "openai"
+	Model    string
+	Endpoint string
+	APIKey   string
+	Referer  string
+	Title    string
+}
+
+// LoadConfigFromEnv reads the same environment variables envTemplate
+// writes into .env, plus OMAI_PROVIDER/OMAI_MODEL/OMAI_ENDPOINT overrides.
+func LoadConfigFromEnv() Config {
+	cfg := Config{
+		Provider: firstNonEmpty(os.Getenv("OMAI_PROVIDER"), "openrouter"),
+		Model:    firstNonEmpty(os.Getenv("OMAI_MODEL"), os.Getenv("OR_MODEL"), "deepseek/deepseek-r1-0528-qwen3-8b"),
+		Endpoint: firstNonEmpty(os.Getenv("OMAI_ENDPOINT"), os.Getenv("OR_ENDPOINT")),
+		APIKey:   firstNonEmpty(os.Getenv("OMAI_API_KEY"), os.Getenv("OPENROUTER_API_KEY"), os.Getenv("ANTHROPIC_API_KEY"), os.Getenv("OPENAI_API_KEY")),
+		Referer:  firstNonEmpty(os.Getenv("OR_REFERER"), "https://omarchy.local"),
+		Title:    firstNonEmpty(os.Getenv("OR_TITLE"), "Omarchy Wagon Wheels"),
+	}
+	return cfg
+}
+
+// NewProvider constructs the Provider selected by cfg.Provider.
+func NewProvider(cfg Config) (Provider, error) {
+	switch cfg.Provider {
+	case "", "openrouter":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("omai: OPENROUTER_API_KEY (or OMAI_API_KEY) is not set")
+		}
+		return NewOpenRouter(cfg.APIKey, cfg.Endpoint, cfg.Referer, cfg.Title), nil
+	case "ollama":
+		return NewOllama(OllamaConfig{Endpoint: cfg.Endpoint, Model: cfg.Model}), nil
+	case "anthropic":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("omai: ANTHROPIC_API_KEY (or OMAI_API_KEY) is not set")
+		}
+		return NewAnthropic(AnthropicConfig{Endpoint: cfg.Endpoint, APIKey: cfg.APIKey}), nil
+	case "openai":
+		if cfg.APIKey == "" {
+			return nil, fmt.Errorf("omai: OPENAI_API_KEY (or OMAI_API_KEY) is not set")
+		}
+		endpoint := firstNonEmpty(cfg.Endpoint, DefaultOpenAIEndpoint)
+		return NewOpenAICompat(OpenAICompatConfig{ProviderName: "openai", Endpoint: endpoint, APIKey: cfg.APIKey}), nil
+	default:
+		return nil, fmt.Errorf("omai: unknown provider %q", cfg.Provider)
+	}
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package omai
+
+import "fmt"
+
+// ErrorKind classifies a provider failure so callers (and RetryPolicy) can
+// react without string-matching error messages.
+type ErrorKind string
+
+const (
+	ErrKindAuth        ErrorKind = "auth"        // bad/missing API key
+	ErrKindRateLimit   ErrorKind = "rate_limit"  // 429 or provider-reported throttling
+	ErrKindTimeout     ErrorKind = "timeout"     // context deadline or network timeout
+	ErrKindBadRequest  ErrorKind = "bad_request" // 4xx other than auth/rate-limit
+	ErrKindServer      ErrorKind = "server"      // 5xx from the provider
+	ErrKindConnection  ErrorKind = "connection"  // dial/transport failure
+	ErrKindUnknownKind ErrorKind = "unknown"
+)
+
+// Error is the structured error type returned by every Provider
+// implementation, so callers can branch on Kind instead of parsing text.
+type Error struct {
+	Kind       ErrorKind
+	Provider   string
+	StatusCode int
+	Message    string
+	Err        error
+}
+
+func (e *Error) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("%s: %s (status %d): %s", e.Provider, e.Kind, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Provider, e.Kind, e.Message)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// IsRetryable reports whether the request that produced err is safe to
+// retry. Rate limits, timeouts, connection failures, and 5xx responses are
+// retryable; auth and bad-request errors are not.
+func IsRetryable(err error) bool {
+	pe, ok := err.(*Error)
+	if !ok {
+		return false
+	}
+	switch pe.Kind {
+	case ErrKindRateLimit, ErrKindTimeout, ErrKindConnection, ErrKindServer:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+package omai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// OllamaConfig configures a provider talking to a local Ollama daemon.
+type OllamaConfig struct {
+	// Endpoint is the base URL, e.g. "http://localhost:11434". No API key
+	// is needed since Ollama is local-only by default.
+	Endpoint   string
+	Model      string
+	HTTPClient *http.Client
+}
+
+type ollamaProvider struct {
+	cfg OllamaConfig
+}
+
+// DefaultOllamaEndpoint is the local Ollama daemon's base URL.
+const DefaultOllamaEndpoint = "http://localhost:11434"
+
+// NewOllama builds a Provider for a local Ollama install.
+func NewOllama(cfg OllamaConfig) Provider {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultOllamaEndpoint
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 120 * time.Second}
+	}
+	return &ollamaProvider{cfg: cfg}
+}
+
+func (p *ollamaProvider) Name() string { return "ollama" }
+
+type ollamaChatRequest struct {
+	Model    string    `json:"model"`
+	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream"`
+}
+
+type ollamaChatResponse struct {
+	Message Message `json:"message"`
+	Done    bool    `json:"done"`
+}
+
+func (p *ollamaProvider) do(ctx context.Context, req Request) (*http.Response, error) {
+	model := req.Model
+	if model == "" {
+		model = p.cfg.Model
+	}
+	body, err := json.Marshal(ollamaChatRequest{Model: model, Messages: req.Messages, Stream: req.Stream})
+	if err != nil {
+		return nil, &Error{Kind: ErrKindConnection, Provider: "ollama", Message: err.Error(), Err: err}
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Endpoint+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, &Error{Kind: ErrKindConnection, Provider: "ollama", Message: err.Error(), Err: err}
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, &Error{Kind: ErrKindConnection, Provider: "ollama", Message: "is the ollama daemon running? " + err.Error(), Err: err}
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(resp.Body)
+		kind := ErrKindBadRequest
+		if resp.StatusCode >= 500 {
+			kind = ErrKindServer
+		}
+		return nil, &Error{Kind: kind, Provider: "ollama", StatusCode: resp.StatusCode, Message: buf.String()}
+	}
+	return resp, nil
+}
+
+func (p *ollamaProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	req.Stream = false
+	resp, err := p.do(ctx, req)
+	if err != nil {
+		return Response{}, err
+	}
+	defer resp.Body.Close()
+
+	var out ollamaChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Response{}, &Error{Kind: ErrKindUnknownKind, Provider: "ollama", Message: err.Error(), Err: err}
+	}
+	return Response{Content: out.Message.Content, Model: req.Model, FinishReason: "stop"}, nil
+}
+
+func (p *ollamaProvider) Stream(ctx context.Context, req Request) (<-chan Chunk, <-chan error) {
+	chunks := make(chan Chunk)
+	errs := make(chan error, 1)
+
+	req.Stream = true
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		resp, err := p.do(ctx, req)
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer resp.Body.Close()
+
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var out ollamaChatResponse
+			if err := dec.Decode(&out); err != nil {
+				return
+			}
+			if out.Done {
+				chunks <- Chunk{FinishReason: "stop", Final: true}
+				return
+			}
+			if out.Message.Content != "" {
+				chunks <- Chunk{Delta: out.Message.Content}
+			}
+		}
+	}()
+
+	return chunks, errs
+}
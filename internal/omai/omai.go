@@ -0,0 +1,99 @@
+// Package omai is the native Go replacement for the old omai.js shim. It
+// defines the provider-agnostic chat client used by cmd/omai and by the
+// wagon-handoff scripts to talk to whichever LLM backend is configured.
+package omai
+
+import (
+	"context"
+	"fmt"
+)
+
+// Role identifies the speaker of a Message, mirroring the chat-completions
+// convention used by every provider we support.
+type Role string
+
+const (
+	RoleSystem    Role = "system"
+	RoleUser      Role = "user"
+	RoleAssistant Role = "assistant"
+)
+
+// Message is a single turn in a conversation.
+type Message struct {
+	Role    Role   `json:"role"`
+	Content string `json:"content"`
+}
+
+// Request describes a chat completion call. Provider implementations map
+// this onto their own wire format.
+type Request struct {
+	Model       string    `json:"model"`
+	Messages    []Message `json:"messages"`
+	Stream      bool      `json:"stream"`
+	Temperature float64   `json:"temperature,omitempty"`
+}
+
+// Chunk is one piece of a streamed response. Final is true on the chunk
+// that carries FinishReason instead of new content.
+type Chunk struct {
+	Delta        string
+	FinishReason string
+	Final        bool
+}
+
+// Response is the fully assembled (non-streaming) result of a chat call.
+type Response struct {
+	Content      string
+	FinishReason string
+	Model        string
+}
+
+// Provider is implemented once per backend (OpenRouter, Ollama, Anthropic,
+// any OpenAI-compatible endpoint). Complete performs a single request/
+// response round trip; Stream does the same but delivers incremental
+// Chunks over the returned channel, closing it when the response ends or
+// ctx is cancelled.
+type Provider interface {
+	Name() string
+	Complete(ctx context.Context, req Request) (Response, error)
+	Stream(ctx context.Context, req Request) (<-chan Chunk, <-chan error)
+}
+
+// Client wraps a Provider with retry/backoff so callers never have to deal
+// with transient network failures directly.
+type Client struct {
+	provider Provider
+	retry    RetryPolicy
+}
+
+// NewClient builds a Client around the given Provider using policy for
+// retries. A zero RetryPolicy falls back to DefaultRetryPolicy.
+func NewClient(provider Provider, policy RetryPolicy) *Client {
+	if policy.MaxAttempts == 0 {
+		policy = DefaultRetryPolicy()
+	}
+	return &Client{provider: provider, retry: policy}
+}
+
+// Complete runs req against the underlying provider, retrying on errors
+// that are classified as retryable by IsRetryable.
+func (c *Client) Complete(ctx context.Context, req Request) (Response, error) {
+	var resp Response
+	err := c.retry.Do(ctx, func() error {
+		var err error
+		resp, err = c.provider.Complete(ctx, req)
+		return err
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("omai: %s: %w", c.provider.Name(), err)
+	}
+	return resp, nil
+}
+
+// Stream runs req against the underlying provider in streaming mode.
+// Retries only apply to establishing the stream, not to chunks already
+// delivered.
+func (c *Client) Stream(ctx context.Context, req Request) (<-chan Chunk, <-chan error) {
+	chunks, errs := c.provider.Stream(ctx, req)
+	return chunks, errs
+}
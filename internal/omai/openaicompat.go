@@ -0,0 +1,201 @@
+package omai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OpenAICompatConfig configures a provider that speaks the OpenAI
+// chat-completions wire format. OpenRouter and any self-hosted
+// OpenAI-compatible gateway (vLLM, LiteLLM, LM Studio's server mode, ...)
+// all fit this shape, so they share one implementation and differ only in
+// endpoint/headers.
+type OpenAICompatConfig struct {
+	// ProviderName is used in error messages and logs, e.g. "openrouter".
+	ProviderName string
+	Endpoint     string
+	APIKey       string
+	// ExtraHeaders are sent on every request, e.g. OpenRouter's
+	// HTTP-Referer/X-Title analytics headers.
+	ExtraHeaders map[string]string
+	HTTPClient   *http.Client
+}
+
+type openAICompatProvider struct {
+	cfg OpenAICompatConfig
+}
+
+// NewOpenAICompat builds a Provider for OpenRouter or any OpenAI-compatible
+// endpoint (self-hosted gateways included).
+func NewOpenAICompat(cfg OpenAICompatConfig) Provider {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 60 * time.Second}
+	}
+	return &openAICompatProvider{cfg: cfg}
+}
+
+func (p *openAICompatProvider) Name() string { return p.cfg.ProviderName }
+
+type openAIChoice struct {
+	Delta        Message `json:"delta"`
+	Message      Message `json:"message"`
+	FinishReason string  `json:"finish_reason"`
+}
+
+type openAIResponse struct {
+	Choices []openAIChoice `json:"choices"`
+	Model   string         `json:"model"`
+}
+
+func (p *openAICompatProvider) newRequest(ctx context.Context, req Request) (*http.Request, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+p.cfg.APIKey)
+	for k, v := range p.cfg.ExtraHeaders {
+		httpReq.Header.Set(k, v)
+	}
+	return httpReq, nil
+}
+
+func (p *openAICompatProvider) classify(resp *http.Response, body []byte) error {
+	kind := ErrKindUnknownKind
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		kind = ErrKindAuth
+	case resp.StatusCode == http.StatusTooManyRequests:
+		kind = ErrKindRateLimit
+	case resp.StatusCode >= 500:
+		kind = ErrKindServer
+	case resp.StatusCode >= 400:
+		kind = ErrKindBadRequest
+	}
+	return &Error{
+		Kind:       kind,
+		Provider:   p.cfg.ProviderName,
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+	}
+}
+
+func (p *openAICompatProvider) Complete(ctx context.Context, req Request) (Response, error) {
+	req.Stream = false
+	httpReq, err := p.newRequest(ctx, req)
+	if err != nil {
+		return Response{}, &Error{Kind: ErrKindConnection, Provider: p.cfg.ProviderName, Message: err.Error(), Err: err}
+	}
+	resp, err := p.cfg.HTTPClient.Do(httpReq)
+	if err != nil {
+		return Response{}, &Error{Kind: ErrKindConnection, Provider: p.cfg.ProviderName, Message: err.Error(), Err: err}
+	}
+	defer resp.Body.Close()
+
+	var out openAIResponse
+	dec := json.NewDecoder(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		buf := new(bytes.Buffer)
+		buf.ReadFrom(resp.Body)
+		return Response{}, p.classify(resp, buf.Bytes())
+	}
+	if err := dec.Decode(&out); err != nil {
+		return Response{}, &Error{Kind: ErrKindUnknownKind, Provider: p.cfg.ProviderName, Message: err.Error(), Err: err}
+	}
+	if len(out.Choices) == 0 {
+		return Response{}, &Error{Kind: ErrKindUnknownKind, Provider: p.cfg.ProviderName, Message: "no choices returned"}
+	}
+	return Response{
+		Content:      out.Choices[0].Message.Content,
+		FinishReason: out.Choices[0].FinishReason,
+		Model:        out.Model,
+	}, nil
+}
+
+func (p *openAICompatProvider) Stream(ctx context.Context, req Request) (<-chan Chunk, <-chan error) {
+	chunks := make(chan Chunk)
+	errs := make(chan error, 1)
+
+	req.Stream = true
+	httpReq, err := p.newRequest(ctx, req)
+	if err != nil {
+		errs <- &Error{Kind: ErrKindConnection, Provider: p.cfg.ProviderName, Message: err.Error(), Err: err}
+		close(chunks)
+		close(errs)
+		return chunks, errs
+	}
+
+	go func() {
+		defer close(chunks)
+		defer close(errs)
+
+		resp, err := p.cfg.HTTPClient.Do(httpReq)
+		if err != nil {
+			errs <- &Error{Kind: ErrKindConnection, Provider: p.cfg.ProviderName, Message: err.Error(), Err: err}
+			return
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			buf := new(bytes.Buffer)
+			buf.ReadFrom(resp.Body)
+			errs <- p.classify(resp, buf.Bytes())
+			return
+		}
+
+		for ev := range scanSSE(resp.Body) {
+			if ev.data == "[DONE]" {
+				return
+			}
+			var out openAIResponse
+			if err := json.Unmarshal([]byte(ev.data), &out); err != nil {
+				continue
+			}
+			if len(out.Choices) == 0 {
+				continue
+			}
+			choice := out.Choices[0]
+			if choice.FinishReason != "" {
+				chunks <- Chunk{FinishReason: choice.FinishReason, Final: true}
+				return
+			}
+			if choice.Delta.Content != "" {
+				chunks <- Chunk{Delta: choice.Delta.Content}
+			}
+		}
+	}()
+
+	return chunks, errs
+}
+
+// DefaultOpenRouterEndpoint is the OpenRouter chat-completions URL used by
+// the old omai.js shim.
+const DefaultOpenRouterEndpoint = "https://openrouter.ai/api/v1/chat/completions"
+
+// DefaultOpenAIEndpoint is the OpenAI chat-completions URL.
+const DefaultOpenAIEndpoint = "https://api.openai.com/v1/chat/completions"
+
+// NewOpenRouter is a convenience wrapper around NewOpenAICompat that fills
+// in OpenRouter's analytics headers.
+func NewOpenRouter(apiKey, endpoint, referer, title string) Provider {
+	if endpoint == "" {
+		endpoint = DefaultOpenRouterEndpoint
+	}
+	return NewOpenAICompat(OpenAICompatConfig{
+		ProviderName: "openrouter",
+		Endpoint:     endpoint,
+		APIKey:       apiKey,
+		ExtraHeaders: map[string]string{
+			"HTTP-Referer": referer,
+			"X-Title":      title,
+		},
+	})
+}
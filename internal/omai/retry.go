@@ -0,0 +1,56 @@
+package omai
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy controls the exponential backoff used by Client.Complete.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy matches what the old omai.js shim effectively had
+// (none) plus a sane ceiling: three attempts, starting at 500ms and
+// doubling up to 8s, with jitter so concurrent subagents don't thunder.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    8 * time.Second,
+	}
+}
+
+// Do invokes fn, retrying with exponential backoff while IsRetryable(err)
+// and attempts remain. It returns the last error seen.
+func (p RetryPolicy) Do(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < p.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if !IsRetryable(err) || attempt == p.MaxAttempts-1 {
+			return err
+		}
+		delay := p.backoff(attempt)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	return err
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt)))
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) / 2))
+	return d/2 + jitter
+}
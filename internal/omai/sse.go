@@ -0,0 +1,38 @@
+package omai
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// sseEvent is one `data: ...` line from a server-sent-events body, with the
+// "data: " prefix already stripped.
+type sseEvent struct {
+	data string
+}
+
+// scanSSE reads an SSE body line by line and sends each "data:" payload on
+// the returned channel, which is closed when r is exhausted or erroring.
+// Comment lines, blank keep-alives, and "event:"/"id:" fields are ignored;
+// none of the providers we target use them for chat completions.
+func scanSSE(r io.Reader) <-chan sseEvent {
+	out := make(chan sseEvent)
+	go func() {
+		defer close(out)
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for sc.Scan() {
+			line := sc.Text()
+			if !strings.HasPrefix(line, "data:") {
+				continue
+			}
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if data == "" {
+				continue
+			}
+			out <- sseEvent{data: data}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,21 @@
+package pkgmgr
+
+// ApkBackend wraps apk, the package manager on Alpine.
+type ApkBackend struct{}
+
+func (ApkBackend) Name() string { return "apk" }
+
+func (ApkBackend) Install(pkg string) error { return run("sudo", "apk", "add", pkg) }
+
+func (ApkBackend) Remove(pkg string) error { return run("sudo", "apk", "del", pkg) }
+
+func (ApkBackend) Search(query string) error { return run("apk", "search", query) }
+
+func (ApkBackend) List() error { return run("apk", "info") }
+
+func (ApkBackend) Update() error {
+	if err := run("sudo", "apk", "update"); err != nil {
+		return err
+	}
+	return run("sudo", "apk", "upgrade")
+}
@@ -0,0 +1,21 @@
+package pkgmgr
+
+// AptBackend wraps apt, the package manager on Debian and Ubuntu.
+type AptBackend struct{}
+
+func (AptBackend) Name() string { return "apt" }
+
+func (AptBackend) Install(pkg string) error { return run("sudo", "apt-get", "install", "-y", pkg) }
+
+func (AptBackend) Remove(pkg string) error { return run("sudo", "apt-get", "remove", "-y", pkg) }
+
+func (AptBackend) Search(query string) error { return run("apt-cache", "search", query) }
+
+func (AptBackend) List() error { return run("apt", "list", "--installed") }
+
+func (AptBackend) Update() error {
+	if err := run("sudo", "apt-get", "update"); err != nil {
+		return err
+	}
+	return run("sudo", "apt-get", "upgrade", "-y")
+}
@@ -0,0 +1,71 @@
+package pkgmgr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// osReleaseIDToBackend maps /etc/os-release's ID (and, for derivatives,
+// ID_LIKE) to the PackageBackend that distro ships by default.
+var osReleaseIDToBackend = map[string]PackageBackend{
+	"arch":    PacmanBackend{},
+	"omarchy": PacmanBackend{},
+	"debian":  AptBackend{},
+	"ubuntu":  AptBackend{},
+	"fedora":  DnfBackend{},
+	"alpine":  ApkBackend{},
+}
+
+// DetectHost reads /etc/os-release and returns the PackageBackend for the
+// running distro, falling back to ID_LIKE for derivatives (e.g. Manjaro's
+// ID_LIKE=arch) before giving up with an error.
+func DetectHost() (PackageBackend, error) {
+	ids, err := osReleaseIDs("/etc/os-release")
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if backend, ok := osReleaseIDToBackend[id]; ok {
+			return backend, nil
+		}
+	}
+	return nil, fmt.Errorf("pkgmgr: no known package backend for os-release ids %v", ids)
+}
+
+// osReleaseIDs returns os-release's ID followed by any ID_LIKE entries, in
+// the order they should be tried.
+func osReleaseIDs(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("pkgmgr: read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var id string
+	var idLike []string
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "ID="):
+			id = unquote(strings.TrimPrefix(line, "ID="))
+		case strings.HasPrefix(line, "ID_LIKE="):
+			idLike = strings.Fields(unquote(strings.TrimPrefix(line, "ID_LIKE=")))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("pkgmgr: scan %s: %w", path, err)
+	}
+	if id == "" {
+		return nil, fmt.Errorf("pkgmgr: %s has no ID= line", path)
+	}
+
+	return append([]string{id}, idLike...), nil
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"`)
+}
@@ -0,0 +1,16 @@
+package pkgmgr
+
+// DnfBackend wraps dnf, the package manager on Fedora.
+type DnfBackend struct{}
+
+func (DnfBackend) Name() string { return "dnf" }
+
+func (DnfBackend) Install(pkg string) error { return run("sudo", "dnf", "install", "-y", pkg) }
+
+func (DnfBackend) Remove(pkg string) error { return run("sudo", "dnf", "remove", "-y", pkg) }
+
+func (DnfBackend) Search(query string) error { return run("dnf", "search", query) }
+
+func (DnfBackend) List() error { return run("dnf", "list", "installed") }
+
+func (DnfBackend) Update() error { return run("sudo", "dnf", "upgrade", "-y") }
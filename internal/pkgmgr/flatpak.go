@@ -0,0 +1,20 @@
+package pkgmgr
+
+// FlatpakBackend wraps flatpak, registered alongside the host's native
+// PackageBackend as a universal overlay for apps the system manager
+// doesn't carry.
+type FlatpakBackend struct{}
+
+func (FlatpakBackend) Name() string { return "flatpak" }
+
+func (FlatpakBackend) Install(pkg string) error {
+	return run("flatpak", "install", "-y", "flathub", pkg)
+}
+
+func (FlatpakBackend) Remove(pkg string) error { return run("flatpak", "uninstall", "-y", pkg) }
+
+func (FlatpakBackend) Search(query string) error { return run("flatpak", "search", query) }
+
+func (FlatpakBackend) List() error { return run("flatpak", "list") }
+
+func (FlatpakBackend) Update() error { return run("flatpak", "update", "-y") }
@@ -0,0 +1,18 @@
+package pkgmgr
+
+// PacmanBackend wraps pacman, the package manager on Arch and Omarchy.
+type PacmanBackend struct{}
+
+func (PacmanBackend) Name() string { return "pacman" }
+
+func (PacmanBackend) Install(pkg string) error {
+	return run("sudo", "pacman", "-S", "--noconfirm", pkg)
+}
+
+func (PacmanBackend) Remove(pkg string) error { return run("sudo", "pacman", "-R", "--noconfirm", pkg) }
+
+func (PacmanBackend) Search(query string) error { return run("pacman", "-Ss", query) }
+
+func (PacmanBackend) List() error { return run("pacman", "-Q") }
+
+func (PacmanBackend) Update() error { return run("sudo", "pacman", "-Syu", "--noconfirm") }
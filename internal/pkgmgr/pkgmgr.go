@@ -0,0 +1,34 @@
+// Package pkgmgr abstracts the host's system package manager so launcher
+// actions behave the same way on Arch/Omarchy (pacman), Debian/Ubuntu
+// (apt), Fedora (dnf), and Alpine (apk), with flatpak available everywhere
+// as a universal overlay for packages the system manager doesn't carry.
+package pkgmgr
+
+import (
+	"os"
+	"os/exec"
+)
+
+// PackageBackend is one system package manager's command surface.
+// Implementations shell out to the real CLI tool rather than linking a
+// package-manager library, matching how this repo already wraps systemd,
+// sops and git as subprocesses instead of their Go bindings.
+type PackageBackend interface {
+	// Name is the backend's identifier, e.g. "pacman", "apt", "flatpak".
+	Name() string
+	Install(pkg string) error
+	Remove(pkg string) error
+	Search(query string) error
+	List() error
+	Update() error
+}
+
+// run execs name with args, streaming its stdout/stderr/stdin through to
+// the launcher's own so output shows up live instead of being buffered.
+func run(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,324 @@
+// Package room replaces the old room.json "breakout room" handoff file
+// with an append-only, size-bounded conversation log: every omai turn is
+// appended to room.log.jsonl, and a separate room-index.json tracks
+// rolling summaries so the log can be compressed instead of growing
+// forever.
+package room
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/zebadiee/omarchy-ai-assist/internal/omai"
+)
+
+// Entry is one line of room.log.jsonl.
+type Entry struct {
+	Timestamp  time.Time `json:"ts"`
+	Source     string    `json:"source"`
+	Topic      string    `json:"topic"`
+	TokensIn   int       `json:"tokens_in"`
+	TokensOut  int       `json:"tokens_out"`
+	Model      string    `json:"model"`
+	PromptHash string    `json:"prompt_hash"`
+	Summary    string    `json:"summary"`
+}
+
+// Index is room-index.json: the rolling summaries produced whenever the
+// log is compressed, plus running totals so token-budget policies can be
+// computed from real usage instead of a static number.
+type Index struct {
+	Summaries       []Entry `json:"summaries"`
+	TotalEntries    int     `json:"total_entries"`
+	TotalTokensIn   int     `json:"total_tokens_in"`
+	TotalTokensOut  int     `json:"total_tokens_out"`
+	LastCompactedAt string  `json:"last_compacted_at,omitempty"`
+}
+
+// Budget configures when Room.MaybeSummarize should compress the oldest
+// window of the log into a single summary entry, mirroring
+// spec.policies.tokenBudgets in the OmServiceSpec.
+type Budget struct {
+	MaxEntries int
+	MaxTokens  int
+}
+
+// DefaultBudget matches the dailyLimit/alertThreshold policy shape used
+// elsewhere in this repo: compress once the log gets unwieldy rather than
+// on every write.
+func DefaultBudget() Budget {
+	return Budget{MaxEntries: 200, MaxTokens: 50_000}
+}
+
+// Room is the append-only log plus its index, rooted at dir (normally
+// ~/.npm-global/omarchy-wagon).
+type Room struct {
+	dir    string
+	budget Budget
+}
+
+// Open returns a Room rooted at dir, creating it if necessary.
+func Open(dir string, budget Budget) (*Room, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Room{dir: dir, budget: budget}, nil
+}
+
+func (r *Room) logPath() string   { return filepath.Join(r.dir, "room.log.jsonl") }
+func (r *Room) indexPath() string { return filepath.Join(r.dir, "room-index.json") }
+
+// HashPrompt derives the PromptHash field from prompt text, so duplicate
+// turns (e.g. a retried subagent call) are identifiable without storing
+// the full prompt in the log.
+func HashPrompt(prompt string) string {
+	sum := sha256.Sum256([]byte(prompt))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Append writes entry to room.log.jsonl and updates the index totals,
+// then compresses the oldest window if the configured Budget is exceeded.
+func (r *Room) Append(ctx context.Context, client *omai.Client, model string, entry Entry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+
+	f, err := os.OpenFile(r.logPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("room: append: %w", err)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("room: marshal entry: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("room: append: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("room: append: %w", err)
+	}
+
+	idx, err := r.readIndex()
+	if err != nil {
+		return err
+	}
+	idx.TotalEntries++
+	idx.TotalTokensIn += entry.TokensIn
+	idx.TotalTokensOut += entry.TokensOut
+	if err := r.writeIndex(idx); err != nil {
+		return err
+	}
+
+	if client != nil {
+		return r.MaybeSummarize(ctx, client, model)
+	}
+	return nil
+}
+
+// Tail returns the last n entries of the log (fewer if the log is
+// shorter).
+func (r *Room) Tail(n int) ([]Entry, error) {
+	entries, err := r.readAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries, nil
+}
+
+// Search returns every entry whose Topic or Summary contains query
+// (case-insensitive).
+func (r *Room) Search(query string) ([]Entry, error) {
+	entries, err := r.readAll()
+	if err != nil {
+		return nil, err
+	}
+	query = strings.ToLower(query)
+	var matched []Entry
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.Topic), query) || strings.Contains(strings.ToLower(e.Summary), query) {
+			matched = append(matched, e)
+		}
+	}
+	return matched, nil
+}
+
+// Clear truncates the log and resets the index, keeping prior summaries
+// (they already represent compressed history, not raw turns).
+func (r *Room) Clear() error {
+	if err := os.WriteFile(r.logPath(), nil, 0o644); err != nil {
+		return err
+	}
+	idx, err := r.readIndex()
+	if err != nil {
+		return err
+	}
+	idx.TotalEntries = 0
+	idx.TotalTokensIn = 0
+	idx.TotalTokensOut = 0
+	return r.writeIndex(idx)
+}
+
+// Index returns the current rolling-summary index.
+func (r *Room) Index() (Index, error) { return r.readIndex() }
+
+// MaybeSummarize compresses the oldest window of the log into a single
+// summary Entry via client once the log crosses budget.MaxEntries entries
+// or budget.MaxTokens total tokens, then truncates the log to the
+// remaining tail.
+func (r *Room) MaybeSummarize(ctx context.Context, client *omai.Client, model string) error {
+	entries, err := r.readAll()
+	if err != nil {
+		return err
+	}
+	idx, err := r.readIndex()
+	if err != nil {
+		return err
+	}
+
+	over := len(entries) > r.budget.MaxEntries || windowTokens(entries) > r.budget.MaxTokens
+	if !over || len(entries) < 2 {
+		return nil
+	}
+
+	windowEnd := len(entries) / 2
+	window := entries[:windowEnd]
+	remaining := entries[windowEnd:]
+
+	summary, err := summarizeWindow(ctx, client, model, window)
+	if err != nil {
+		return fmt.Errorf("room: summarize: %w", err)
+	}
+
+	idx.Summaries = append(idx.Summaries, Entry{
+		Timestamp: time.Now().UTC(),
+		Source:    "room-summarizer",
+		Topic:     "compacted-window",
+		Model:     model,
+		Summary:   summary,
+	})
+	idx.LastCompactedAt = time.Now().UTC().Format(time.RFC3339)
+
+	if err := r.writeIndex(idx); err != nil {
+		return err
+	}
+	return r.rewriteLog(remaining)
+}
+
+// windowTokens sums TokensIn+TokensOut over the log's current entries.
+// idx.TotalTokensIn/TotalTokensOut are lifetime counters that never shrink,
+// so comparing against those kept MaybeSummarize permanently over budget
+// (and re-compacting on every Append) once the room had ever crossed
+// MaxTokens, even right after a compaction had trimmed the live log back
+// down. The budget check needs the size of what's actually still in
+// room.log.jsonl.
+func windowTokens(entries []Entry) int {
+	var total int
+	for _, e := range entries {
+		total += e.TokensIn + e.TokensOut
+	}
+	return total
+}
+
+func summarizeWindow(ctx context.Context, client *omai.Client, model string, window []Entry) (string, error) {
+	var b strings.Builder
+	for _, e := range window {
+		fmt.Fprintf(&b, "[%s/%s] %s\n", e.Source, e.Topic, e.Summary)
+	}
+	resp, err := client.Complete(ctx, omai.Request{
+		Model: model,
+		Messages: []omai.Message{
+			{Role: omai.RoleSystem, Content: "Compress the following Omarchy breakout-room turns into one short paragraph preserving decisions and open questions."},
+			{Role: omai.RoleUser, Content: b.String()},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(resp.Content), nil
+}
+
+func (r *Room) readAll() ([]Entry, error) {
+	f, err := os.Open(r.logPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, sc.Err()
+}
+
+func (r *Room) rewriteLog(entries []Entry) error {
+	tmp := r.logPath() + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.logPath())
+}
+
+func (r *Room) readIndex() (Index, error) {
+	data, err := os.ReadFile(r.indexPath())
+	if os.IsNotExist(err) {
+		return Index{}, nil
+	}
+	if err != nil {
+		return Index{}, err
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return Index{}, fmt.Errorf("room: parse index: %w", err)
+	}
+	return idx, nil
+}
+
+func (r *Room) writeIndex(idx Index) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.indexPath(), data, 0o644)
+}
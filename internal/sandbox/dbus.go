@@ -0,0 +1,68 @@
+package sandbox
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sync/atomic"
+	"time"
+)
+
+// proxySeq disambiguates concurrent sandboxes started by the same launcher
+// process, since the socket path has to be chosen before xdg-dbus-proxy (and
+// therefore the PID it will bind to) exists.
+var proxySeq int64
+
+// startDBusProxy spawns xdg-dbus-proxy on a fresh socket under
+// ~/.config/omarchy/launcher/share/<pid>-<seq>.bus, filtered to profile's
+// talk/own/call name lists, and blocks until the socket is ready for the
+// sandboxed child to bind-mount.
+func startDBusProxy(profile SandboxProfile) (*exec.Cmd, string, error) {
+	realBus := os.Getenv("DBUS_SESSION_BUS_ADDRESS")
+	if realBus == "" {
+		return nil, "", fmt.Errorf("sandbox: DBUS_SESSION_BUS_ADDRESS is not set, cannot broker D-Bus access")
+	}
+
+	dir, err := shareDir()
+	if err != nil {
+		return nil, "", err
+	}
+	seq := atomic.AddInt64(&proxySeq, 1)
+	busPath := busSocketPath(dir, os.Getpid(), seq)
+
+	argv := []string{realBus, busPath, "--filter"}
+	for _, name := range profile.TalkNames {
+		argv = append(argv, "--talk="+name)
+	}
+	for _, name := range profile.OwnNames {
+		argv = append(argv, "--own="+name)
+	}
+	for _, name := range profile.CallNames {
+		argv = append(argv, "--call="+name)
+	}
+
+	proxy := exec.Command("xdg-dbus-proxy", argv...)
+	if err := proxy.Start(); err != nil {
+		return nil, "", fmt.Errorf("sandbox: start xdg-dbus-proxy: %w", err)
+	}
+
+	if err := waitForSocket(busPath, 2*time.Second); err != nil {
+		_ = proxy.Process.Kill()
+		return nil, "", fmt.Errorf("sandbox: xdg-dbus-proxy socket never appeared: %w", err)
+	}
+
+	return proxy, busPath, nil
+}
+
+// waitForSocket polls for path to exist, since xdg-dbus-proxy creates its
+// listening socket asynchronously after Start returns.
+func waitForSocket(path string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(path); err == nil {
+			return nil
+		}
+		time.Sleep(25 * time.Millisecond)
+	}
+	return fmt.Errorf("timed out waiting for %s", path)
+}
@@ -0,0 +1,247 @@
+// Package sandbox wraps a launcher action's command in a bubblewrap (bwrap)
+// namespace, brokering D-Bus access through xdg-dbus-proxy when requested,
+// in the style of the fortify/ego project: a command declares what session
+// resources it needs (Wayland, X11, PulseAudio, network, D-Bus names)
+// instead of inheriting the caller's full session access by default.
+package sandbox
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Enablement is a bitfield of session resources a SandboxProfile may share
+// into the sandboxed child. The zero value shares nothing beyond /usr,
+// /etc and a private /tmp.
+type Enablement uint8
+
+const (
+	Wayland Enablement = 1 << iota
+	X11
+	DBus
+	Pulse
+	Net
+	// Filesystem gates ExtraBinds/ReadOnlyBinds: without it, a profile's
+	// bind lists are ignored and the child sees only /usr, /etc and a
+	// private /tmp, regardless of what's configured.
+	Filesystem
+)
+
+// Has reports whether e includes flag.
+func (e Enablement) Has(flag Enablement) bool {
+	return e&flag != 0
+}
+
+// SandboxProfile declares what a LauncherAction may access once it's run
+// under bwrap. TalkNames/OwnNames/CallNames are only consulted when
+// Enable.Has(DBus); they're passed straight through to xdg-dbus-proxy's
+// --talk=/--own=/--call= filters (e.g. "org.mpris.MediaPlayer2.*").
+// ExtraBinds/ReadOnlyBinds are only consulted when Enable.Has(Filesystem).
+type SandboxProfile struct {
+	Enable         Enablement `json:"enable" toml:"enable"`
+	TalkNames      []string   `json:"talk_names,omitempty" toml:"talk_names,omitempty"`
+	OwnNames       []string   `json:"own_names,omitempty" toml:"own_names,omitempty"`
+	CallNames      []string   `json:"call_names,omitempty" toml:"call_names,omitempty"`
+	ExtraBinds     []string   `json:"extra_binds,omitempty" toml:"extra_binds,omitempty"`         // host paths bind-mounted read-write
+	ReadOnlyBinds  []string   `json:"read_only_binds,omitempty" toml:"read_only_binds,omitempty"` // host paths bind-mounted read-only
+	SeccompProfile string     `json:"seccomp_profile,omitempty" toml:"seccomp_profile,omitempty"` // path to a pre-compiled BPF program for bwrap's --seccomp
+}
+
+// Handle is one running sandboxed command, returned by Start so the caller
+// can Wait for it and the teardown it triggers.
+type Handle struct {
+	Cmd      *exec.Cmd
+	proxyCmd *exec.Cmd
+	state    string
+}
+
+// Wait blocks until the sandboxed command exits, then kills its
+// xdg-dbus-proxy (if any) and removes its state file.
+func (h *Handle) Wait() error {
+	waitErr := h.Cmd.Wait()
+	h.teardown()
+	return waitErr
+}
+
+func (h *Handle) teardown() {
+	if h.proxyCmd != nil && h.proxyCmd.Process != nil {
+		_ = h.proxyCmd.Process.Kill()
+		_ = h.proxyCmd.Wait()
+	}
+	if h.state != "" {
+		_ = removeState(h.state)
+	}
+}
+
+// Start builds a bwrap argv for profile wrapping command/args, starts an
+// xdg-dbus-proxy first if the profile enables DBus, and launches the
+// sandboxed child. The child's stdio is connected to the caller's.
+func Start(profile SandboxProfile, command string, args []string) (*Handle, error) {
+	return start(profile, command, args, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// StartCaptured behaves like Start but buffers the sandboxed child's
+// stdout/stderr instead of connecting them to the caller's own, for
+// non-interactive callers (e.g. actionsapi's invoke endpoint) that need
+// to report captured output rather than attach a terminal.
+func StartCaptured(profile SandboxProfile, command string, args []string) (exitCode int, stdout, stderr string, err error) {
+	var outBuf, errBuf bytes.Buffer
+	handle, startErr := start(profile, command, args, nil, &outBuf, &errBuf)
+	if startErr != nil {
+		return 1, "", "", startErr
+	}
+	waitErr := handle.Wait()
+	return exitCodeFromErr(waitErr), outBuf.String(), errBuf.String(), waitErr
+}
+
+func exitCodeFromErr(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+	return 1
+}
+
+// start does the work shared by Start and StartCaptured, taking the
+// child's stdio explicitly so the two can differ in how they wire it.
+func start(profile SandboxProfile, command string, args []string, stdin io.Reader, stdout, stderr io.Writer) (*Handle, error) {
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = fmt.Sprintf("/run/user/%d", os.Getuid())
+	}
+
+	bwrapArgv := []string{
+		"--ro-bind", "/usr", "/usr",
+		"--ro-bind", "/etc", "/etc",
+		"--symlink", "/usr/lib", "/lib",
+		"--symlink", "/usr/lib64", "/lib64",
+		"--symlink", "/usr/bin", "/bin",
+		"--proc", "/proc",
+		"--dev", "/dev",
+		"--tmpfs", "/tmp",
+		"--die-with-parent",
+		"--unshare-all",
+		// Without --clearenv, bwrap forwards its own process environment
+		// straight through to the child -- including anything the caller
+		// has set (API keys, tokens), regardless of what the profile
+		// enables. Only what's explicitly --setenv'd below reaches the
+		// sandboxed command.
+		"--clearenv",
+		"--setenv", "PATH", "/usr/bin:/usr/local/bin",
+	}
+	if profile.Enable.Has(Net) {
+		bwrapArgv = append(bwrapArgv, "--share-net")
+	}
+	if profile.Enable.Has(Wayland) || profile.Enable.Has(Pulse) {
+		bwrapArgv = append(bwrapArgv, "--setenv", "XDG_RUNTIME_DIR", runtimeDir)
+	}
+	if profile.Enable.Has(Wayland) {
+		sockets, _ := filepath.Glob(filepath.Join(runtimeDir, "wayland-*"))
+		for _, sock := range sockets {
+			bwrapArgv = append(bwrapArgv, "--bind", sock, sock)
+		}
+		if display := os.Getenv("WAYLAND_DISPLAY"); display != "" {
+			bwrapArgv = append(bwrapArgv, "--setenv", "WAYLAND_DISPLAY", display)
+		}
+	}
+	if profile.Enable.Has(X11) {
+		bwrapArgv = append(bwrapArgv, "--bind-try", "/tmp/.X11-unix", "/tmp/.X11-unix")
+		if display := os.Getenv("DISPLAY"); display != "" {
+			bwrapArgv = append(bwrapArgv, "--setenv", "DISPLAY", display)
+		}
+	}
+	if profile.Enable.Has(Pulse) {
+		cookie := filepath.Join(os.Getenv("HOME"), ".config", "pulse", "cookie")
+		if _, err := os.Stat(cookie); err == nil {
+			bwrapArgv = append(bwrapArgv, "--ro-bind", cookie, "/root/.config/pulse/cookie")
+		}
+		socket := filepath.Join(runtimeDir, "pulse", "native")
+		bwrapArgv = append(bwrapArgv, "--bind-try", socket, socket)
+	}
+	if profile.Enable.Has(Filesystem) {
+		for _, bind := range profile.ReadOnlyBinds {
+			bwrapArgv = append(bwrapArgv, "--ro-bind", bind, bind)
+		}
+		for _, bind := range profile.ExtraBinds {
+			bwrapArgv = append(bwrapArgv, "--bind", bind, bind)
+		}
+	}
+
+	var seccompFile *os.File
+	if profile.SeccompProfile != "" {
+		f, err := os.Open(profile.SeccompProfile)
+		if err != nil {
+			return nil, fmt.Errorf("sandbox: open seccomp profile %s: %w", profile.SeccompProfile, err)
+		}
+		seccompFile = f
+		// bwrap reads the compiled BPF program off an inherited fd; cmd.ExtraFiles
+		// below puts it at fd 3, since fds 0-2 are the child's stdio.
+		bwrapArgv = append(bwrapArgv, "--seccomp", "3")
+	}
+
+	var proxyCmd *exec.Cmd
+	var statePath string
+
+	if profile.Enable.Has(DBus) {
+		proxy, busPath, err := startDBusProxy(profile)
+		if err != nil {
+			if seccompFile != nil {
+				seccompFile.Close()
+			}
+			return nil, err
+		}
+		proxyCmd = proxy
+		bwrapArgv = append(bwrapArgv, "--bind", busPath, busPath)
+		bwrapArgv = append(bwrapArgv, "--setenv", "DBUS_SESSION_BUS_ADDRESS", "unix:path="+busPath)
+
+		statePath, err = writeState(sandboxState{
+			ChildBusPath: busPath,
+			ProxyPID:     proxyCmd.Process.Pid,
+		})
+		if err != nil {
+			_ = proxyCmd.Process.Kill()
+			if seccompFile != nil {
+				seccompFile.Close()
+			}
+			return nil, err
+		}
+	}
+
+	bwrapArgv = append(bwrapArgv, "--", command)
+	bwrapArgv = append(bwrapArgv, args...)
+
+	cmd := exec.Command("bwrap", bwrapArgv...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	// cmd.Env is bwrap's own environment, not the sandboxed child's --
+	// --clearenv/--setenv above control what the child actually sees.
+	cmd.Env = os.Environ()
+	if seccompFile != nil {
+		cmd.ExtraFiles = []*os.File{seccompFile}
+	}
+
+	startErr := cmd.Start()
+	if seccompFile != nil {
+		// The child now holds its own duplicated fd 3; the parent's copy
+		// would otherwise leak for the lifetime of the launcher process.
+		seccompFile.Close()
+	}
+	if startErr != nil {
+		if proxyCmd != nil && proxyCmd.Process != nil {
+			_ = proxyCmd.Process.Kill()
+		}
+		if statePath != "" {
+			_ = removeState(statePath)
+		}
+		return nil, fmt.Errorf("sandbox: start bwrap: %w", startErr)
+	}
+
+	return &Handle{Cmd: cmd, proxyCmd: proxyCmd, state: statePath}, nil
+}
@@ -0,0 +1,130 @@
+package sandbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// shareDir is where active sandboxes' D-Bus sockets and state files live:
+// ~/.config/omarchy/launcher/share.
+func shareDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("sandbox: resolve home dir: %w", err)
+	}
+	dir := filepath.Join(home, ".config", "omarchy", "launcher", "share")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("sandbox: create %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// busSocketPath is the xdg-dbus-proxy socket path for one (launcher PID,
+// sequence number) pair.
+func busSocketPath(dir string, pid int, seq int64) string {
+	return filepath.Join(dir, fmt.Sprintf("%d-%d.bus", pid, seq))
+}
+
+// sandboxState is the JSON sidecar written next to an active D-Bus proxy
+// socket (<socket>.json) so a Cleanup run — possibly from a different
+// process, after a crash — can tell whether the proxy is still needed.
+type sandboxState struct {
+	ChildBusPath string    `json:"child_bus_path"`
+	ProxyPID     int       `json:"proxy_pid"`
+	StartedAt    time.Time `json:"started_at"`
+}
+
+func writeState(s sandboxState) (string, error) {
+	s.StartedAt = time.Now()
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("sandbox: marshal state: %w", err)
+	}
+	path := s.ChildBusPath + ".json"
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("sandbox: write state %s: %w", path, err)
+	}
+	return path, nil
+}
+
+func removeState(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("sandbox: remove state %s: %w", path, err)
+	}
+	busPath := path[:len(path)-len(".json")]
+	if err := os.Remove(busPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("sandbox: remove socket %s: %w", busPath, err)
+	}
+	return nil
+}
+
+// processAlive reports whether pid names a running process, by sending it
+// the null signal (the standard os.FindProcess-always-succeeds-on-Unix
+// workaround).
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return proc.Signal(syscall.Signal(0)) == nil
+}
+
+// Cleanup tears down any xdg-dbus-proxy left behind by a launcher process
+// that crashed before it could call Handle.Wait. The socket filename
+// encodes the launcher's PID (see busSocketPath); if that PID is no longer
+// running, its sandboxed child can't be either, so the proxy is killed and
+// its socket and state file removed. It's meant to be run periodically, or
+// once on launcher startup.
+func Cleanup() error {
+	dir, err := shareDir()
+	if err != nil {
+		return err
+	}
+
+	entries, err := filepath.Glob(filepath.Join(dir, "*.bus.json"))
+	if err != nil {
+		return fmt.Errorf("sandbox: glob %s: %w", dir, err)
+	}
+
+	for _, path := range entries {
+		launcherPID, ok := launcherPIDFromStatePath(path)
+		if !ok || processAlive(launcherPID) {
+			continue
+		}
+
+		if data, err := os.ReadFile(path); err == nil {
+			var s sandboxState
+			if json.Unmarshal(data, &s) == nil && processAlive(s.ProxyPID) {
+				if proc, err := os.FindProcess(s.ProxyPID); err == nil {
+					_ = proc.Kill()
+				}
+			}
+		}
+
+		_ = removeState(path)
+	}
+
+	return nil
+}
+
+// launcherPIDFromStatePath extracts the launcher PID encoded in a
+// "<pid>-<seq>.bus.json" state filename.
+func launcherPIDFromStatePath(path string) (int, bool) {
+	base := filepath.Base(path)
+	base = strings.TrimSuffix(base, ".bus.json")
+	pidPart, _, found := strings.Cut(base, "-")
+	if !found {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(pidPart)
+	if err != nil {
+		return 0, false
+	}
+	return pid, true
+}
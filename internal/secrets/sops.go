@@ -0,0 +1,104 @@
+// Package secrets integrates the handbook generator with SOPS so the
+// .env template and OmServiceSpec secretRefs resolve to something real
+// instead of a plaintext API key sitting on disk.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CreationRule is one entry of a .sops.yaml `creation_rules` list: any file
+// whose path matches PathRegex gets encrypted for the given recipients.
+type CreationRule struct {
+	PathRegex string
+	Age       []string
+	PGP       []string
+}
+
+// CreationRulesForHostUser builds the single creation rule this repo needs:
+// everything under secrets.env.enc (or any *.enc file) for the given host
+// and user is encrypted to that user's age key plus any shared PGP
+// recipients (e.g. an ops team key), matching the multi-recipient pattern
+// common in dotfiles-as-Nix setups.
+func CreationRulesForHostUser(host, user string, ageRecipients, pgpFingerprints []string) []CreationRule {
+	return []CreationRule{
+		{
+			PathRegex: fmt.Sprintf(`^configs/(%s/)?%s.*\.enc$`, host, user),
+			Age:       ageRecipients,
+			PGP:       pgpFingerprints,
+		},
+	}
+}
+
+// WriteSopsConfig renders rules as a .sops.yaml and writes it to path.
+func WriteSopsConfig(path string, rules []CreationRule) error {
+	var b strings.Builder
+	b.WriteString("creation_rules:\n")
+	for _, r := range rules {
+		fmt.Fprintf(&b, "  - path_regex: %q\n", r.PathRegex)
+		if len(r.Age) > 0 {
+			fmt.Fprintf(&b, "    age: %q\n", strings.Join(r.Age, ","))
+		}
+		if len(r.PGP) > 0 {
+			fmt.Fprintf(&b, "    pgp: %q\n", strings.Join(r.PGP, ","))
+		}
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// Available reports whether the sops binary is on PATH. Callers use this
+// to decide whether to encrypt in place or leave a plaintext file with a
+// warning for the operator to encrypt manually.
+func Available() bool {
+	_, err := exec.LookPath("sops")
+	return err == nil
+}
+
+// EncryptInPlace runs `sops -e -i path`, encrypting the file using the
+// creation rules in the nearest .sops.yaml.
+func EncryptInPlace(ctx context.Context, path string) error {
+	return run(ctx, "-e", "-i", path)
+}
+
+// Decrypt returns the plaintext contents of an encrypted file, equivalent
+// to `sops -d path`.
+func Decrypt(ctx context.Context, path string) (string, error) {
+	cmd := exec.CommandContext(ctx, "sops", "-d", path)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("secrets: sops decrypt %s: %w", path, err)
+	}
+	return string(out), nil
+}
+
+// Edit opens path in the user's $EDITOR via `sops path`, re-encrypting on
+// save.
+func Edit(ctx context.Context, path string) error {
+	cmd := exec.CommandContext(ctx, "sops", path)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("secrets: sops edit %s: %w", path, err)
+	}
+	return nil
+}
+
+// Rotate re-encrypts path with fresh data keys (`sops rotate -i path`),
+// without changing the plaintext content.
+func Rotate(ctx context.Context, path string) error {
+	return run(ctx, "rotate", "-i", path)
+}
+
+func run(ctx context.Context, args ...string) error {
+	cmd := exec.CommandContext(ctx, "sops", args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("secrets: sops %s: %w", strings.Join(args, " "), err)
+	}
+	return nil
+}
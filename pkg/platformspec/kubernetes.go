@@ -0,0 +1,77 @@
+package platformspec
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToKubernetesManifests renders spec as a multi-document YAML string — one
+// Deployment, Service, and (when Exposure.Ingress is set) Ingress per
+// Service — so the same OmServiceSpec that drives the local Omarchy deploy
+// can also stand up a real cluster.
+func ToKubernetesManifests(spec OmServiceSpec) (string, error) {
+	var b strings.Builder
+	for i, svc := range spec.Spec.Services {
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		writeDeployment(&b, svc)
+		b.WriteString("---\n")
+		writeService(&b, svc)
+		if svc.Exposure.Ingress != nil {
+			b.WriteString("---\n")
+			writeIngress(&b, svc)
+		}
+	}
+	return b.String(), nil
+}
+
+func writeDeployment(b *strings.Builder, svc Service) {
+	fmt.Fprintf(b, "apiVersion: apps/v1\n")
+	fmt.Fprintf(b, "kind: Deployment\n")
+	fmt.Fprintf(b, "metadata:\n  name: %s\n", svc.Name)
+	fmt.Fprintf(b, "spec:\n")
+	fmt.Fprintf(b, "  replicas: %d\n", svc.Runtime.Replicas)
+	fmt.Fprintf(b, "  selector:\n    matchLabels:\n      app: %s\n", svc.Name)
+	fmt.Fprintf(b, "  template:\n    metadata:\n      labels:\n        app: %s\n", svc.Name)
+	fmt.Fprintf(b, "    spec:\n      containers:\n        - name: %s\n          image: %s\n", svc.Name, svc.Runtime.Image)
+	if len(svc.Runtime.Command) > 0 {
+		b.WriteString("          command:\n")
+		for _, c := range svc.Runtime.Command {
+			fmt.Fprintf(b, "            - %q\n", c)
+		}
+	}
+	if svc.Exposure.Port != 0 {
+		fmt.Fprintf(b, "          ports:\n            - containerPort: %d\n", svc.Exposure.Port)
+	}
+	if len(svc.Runtime.Environment) > 0 {
+		b.WriteString("          env:\n")
+		for _, e := range svc.Runtime.Environment {
+			fmt.Fprintf(b, "            - name: %s\n              value: %q\n", e.Name, e.Value)
+		}
+	}
+	if len(svc.Runtime.Resources) > 0 {
+		b.WriteString("          resources:\n            requests:\n")
+		for k, v := range svc.Runtime.Resources {
+			fmt.Fprintf(b, "              %s: %q\n", k, v)
+		}
+	}
+}
+
+func writeService(b *strings.Builder, svc Service) {
+	fmt.Fprintf(b, "apiVersion: v1\n")
+	fmt.Fprintf(b, "kind: Service\n")
+	fmt.Fprintf(b, "metadata:\n  name: %s\n", svc.Name)
+	fmt.Fprintf(b, "spec:\n  selector:\n    app: %s\n", svc.Name)
+	fmt.Fprintf(b, "  ports:\n    - port: %d\n      targetPort: %d\n", svc.Exposure.Port, svc.Exposure.Port)
+}
+
+func writeIngress(b *strings.Builder, svc Service) {
+	fmt.Fprintf(b, "apiVersion: networking.k8s.io/v1\n")
+	fmt.Fprintf(b, "kind: Ingress\n")
+	fmt.Fprintf(b, "metadata:\n  name: %s\n", svc.Name)
+	fmt.Fprintf(b, "spec:\n  rules:\n")
+	fmt.Fprintf(b, "    - host: %s\n      http:\n        paths:\n", svc.Exposure.Ingress.Host)
+	fmt.Fprintf(b, "          - path: /\n            pathType: Prefix\n")
+	fmt.Fprintf(b, "            backend:\n              service:\n                name: %s\n                port:\n                  number: %d\n", svc.Name, svc.Exposure.Port)
+}
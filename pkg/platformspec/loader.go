@@ -0,0 +1,66 @@
+package platformspec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadServicesFromDir reads every *.yaml, *.yml, and *.hcl file in dir as a
+// single Service manifest and returns them all, so cmd/handbook's
+// --services-from flag can compose Spec.Services out of independently
+// authored files instead of editing buildOmServiceSpec by hand. Files are
+// processed in directory order for reproducible output.
+func LoadServicesFromDir(dir string) ([]Service, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("platformspec: read %s: %w", dir, err)
+	}
+
+	var services []Service
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml":
+			svc, err := loadYAMLService(path)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, svc)
+		case ".hcl":
+			svc, err := loadHCLService(path)
+			if err != nil {
+				return nil, err
+			}
+			services = append(services, svc)
+		}
+	}
+	return services, nil
+}
+
+func loadYAMLService(path string) (Service, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Service{}, fmt.Errorf("platformspec: read %s: %w", path, err)
+	}
+	var svc Service
+	if err := yaml.Unmarshal(data, &svc); err != nil {
+		return Service{}, fmt.Errorf("platformspec: parse %s: %w", path, err)
+	}
+	return svc, nil
+}
+
+func loadHCLService(path string) (Service, error) {
+	var svc Service
+	if err := hclsimple.DecodeFile(path, nil, &svc); err != nil {
+		return Service{}, fmt.Errorf("platformspec: parse %s: %w", path, err)
+	}
+	return svc, nil
+}
@@ -0,0 +1,19 @@
+package platformspec
+
+import "encoding/json"
+
+// Marshal renders spec as indented JSON, the same shape writeJSONLD has
+// always written to configs/ai-token.jsonld.
+func Marshal(spec OmServiceSpec) ([]byte, error) {
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// Unmarshal parses data produced by Marshal (or any compatible
+// OmServiceSpec JSON document) back into a typed spec.
+func Unmarshal(data []byte) (OmServiceSpec, error) {
+	var spec OmServiceSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return OmServiceSpec{}, err
+	}
+	return spec, nil
+}
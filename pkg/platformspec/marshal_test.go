@@ -0,0 +1,83 @@
+package platformspec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func sampleSpec() OmServiceSpec {
+	return OmServiceSpec{
+		APIVersion: "platformspec/v1",
+		Kind:       "OmServiceSpec",
+		Metadata:   Metadata{Name: "ai-token-manager"},
+		Spec: Spec{
+			Services: []Service{
+				{
+					Name: "om-api",
+					Type: "container",
+					Runtime: Runtime{
+						Image:    "ghcr.io/zebadiee/om-api:latest",
+						Command:  []string{"/bin/om-api"},
+						Replicas: 2,
+						Resources: map[string]string{
+							"cpu": "500m",
+						},
+						Environment: []EnvVar{
+							{Name: "OMAI_PROVIDER", Value: "openrouter"},
+						},
+						Secrets: []SecretRef{
+							{Name: "openrouter-api-key", SecretRef: "sops://secrets.env.enc#OPENROUTER_API_KEY"},
+						},
+					},
+					Exposure: Exposure{
+						Port:    8080,
+						Ingress: &Ingress{Host: "om-api.local"},
+					},
+					Dependencies: []Dependency{
+						{Name: "om-db", Type: "database", Service: "postgres"},
+					},
+					Observability: Observability{
+						Logs:    &LogSink{Sink: "loki"},
+						Metrics: &MetricsSink{Dashboard: "grafana.internal/d/om-api"},
+					},
+				},
+			},
+			Policies: Policies{
+				TokenBudgets: TokenBudgets{DailyLimit: 50_000, AlertThreshold: 0.8},
+			},
+			Webhooks: []Webhook{
+				{Event: "deploy", URL: "https://hooks.example.com/deploy"},
+			},
+		},
+	}
+}
+
+// TestMarshalUnmarshalRoundTrip confirms Unmarshal(Marshal(spec)) reproduces
+// spec exactly, since every other consumer (FilesystemEmitter,
+// NixModuleEmitter, cmd/handbook's verify reconciler) depends on that
+// round-trip being lossless.
+func TestMarshalUnmarshalRoundTrip(t *testing.T) {
+	want := sampleSpec()
+
+	data, err := Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	got, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Fatalf("round trip mismatch:\nwant: %+v\ngot:  %+v", want, got)
+	}
+}
+
+// TestUnmarshalInvalidJSON confirms Unmarshal surfaces the underlying
+// encoding/json error instead of silently returning a zero-value spec.
+func TestUnmarshalInvalidJSON(t *testing.T) {
+	if _, err := Unmarshal([]byte("{not json")); err == nil {
+		t.Fatal("Unmarshal: expected error for malformed JSON, got nil")
+	}
+}
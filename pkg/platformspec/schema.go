@@ -0,0 +1,132 @@
+package platformspec
+
+import "encoding/json"
+
+// ExportSchema returns a JSON Schema (draft 2020-12) document describing
+// OmServiceSpec, hand-written from the same fields/`validate` rules in
+// types.go rather than reflected at runtime, so it stays exact and so
+// editors get useful completion/validation on configs/ai-token.jsonld and
+// on the manifests --services-from reads.
+func ExportSchema() ([]byte, error) {
+	schema := map[string]any{
+		"$schema":  "https://json-schema.org/draft/2020-12/schema",
+		"$id":      "https://omarchy.local/schemas/om-service-spec.json",
+		"title":    "OmServiceSpec",
+		"type":     "object",
+		"required": []string{"apiVersion", "kind", "metadata", "spec"},
+		"properties": map[string]any{
+			"apiVersion": map[string]any{"const": "platformspec/v1"},
+			"kind":       map[string]any{"const": "OmServiceSpec"},
+			"metadata": map[string]any{
+				"type":     "object",
+				"required": []string{"name"},
+				"properties": map[string]any{
+					"name": map[string]any{"type": "string"},
+				},
+			},
+			"spec": map[string]any{
+				"type":     "object",
+				"required": []string{"services"},
+				"properties": map[string]any{
+					"services": map[string]any{
+						"type":     "array",
+						"minItems": 1,
+						"items":    map[string]any{"$ref": "#/$defs/service"},
+					},
+					"policies": map[string]any{"$ref": "#/$defs/policies"},
+					"webhooks": map[string]any{
+						"type":  "array",
+						"items": map[string]any{"$ref": "#/$defs/webhook"},
+					},
+				},
+			},
+		},
+		"$defs": map[string]any{
+			"service": map[string]any{
+				"type":     "object",
+				"required": []string{"name", "type", "runtime"},
+				"properties": map[string]any{
+					"name":    map[string]any{"type": "string"},
+					"type":    map[string]any{"enum": []string{"container", "process"}},
+					"runtime": map[string]any{"$ref": "#/$defs/runtime"},
+					"exposure": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"port": map[string]any{"type": "integer", "minimum": 1, "maximum": 65535},
+							"ingress": map[string]any{
+								"type":       "object",
+								"properties": map[string]any{"host": map[string]any{"type": "string"}},
+							},
+						},
+					},
+					"dependencies": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type": "object",
+							"properties": map[string]any{
+								"name":    map[string]any{"type": "string"},
+								"type":    map[string]any{"type": "string"},
+								"service": map[string]any{"type": "string"},
+							},
+						},
+					},
+					"observability": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"logs":    map[string]any{"type": "object"},
+							"metrics": map[string]any{"type": "object"},
+						},
+					},
+				},
+			},
+			"runtime": map[string]any{
+				"type":     "object",
+				"required": []string{"image", "replicas"},
+				"properties": map[string]any{
+					"image":    map[string]any{"type": "string"},
+					"command":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+					"replicas": map[string]any{"type": "integer", "minimum": 1},
+					"resources": map[string]any{
+						"type":                 "object",
+						"additionalProperties": map[string]any{"type": "string"},
+					},
+					"environment": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type":       "object",
+							"properties": map[string]any{"name": map[string]any{"type": "string"}, "value": map[string]any{"type": "string"}},
+						},
+					},
+					"secrets": map[string]any{
+						"type": "array",
+						"items": map[string]any{
+							"type":       "object",
+							"properties": map[string]any{"name": map[string]any{"type": "string"}, "secretRef": map[string]any{"type": "string"}},
+						},
+					},
+				},
+			},
+			"policies": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"tokenBudgets": map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"dailyLimit":     map[string]any{"type": "integer", "minimum": 0},
+							"alertThreshold": map[string]any{"type": "number", "minimum": 0, "maximum": 1},
+						},
+					},
+				},
+			},
+			"webhook": map[string]any{
+				"type":     "object",
+				"required": []string{"event", "url"},
+				"properties": map[string]any{
+					"event": map[string]any{"type": "string"},
+					"url":   map[string]any{"type": "string", "format": "uri"},
+				},
+			},
+		},
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
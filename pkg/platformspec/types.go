@@ -0,0 +1,119 @@
+// Package platformspec is the typed counterpart to the map[string]any
+// OmServiceSpec that cmd/handbook used to build by hand. It gives the
+// generator (and anything else that wants to compose service manifests,
+// export a schema, or render Kubernetes objects) a single source of truth
+// with real validation instead of untyped maps.
+package platformspec
+
+// OmServiceSpec is the root document emitted to configs/ai-token.jsonld.
+type OmServiceSpec struct {
+	APIVersion string   `json:"apiVersion" validate:"required,eq=platformspec/v1"`
+	Kind       string   `json:"kind" validate:"required,eq=OmServiceSpec"`
+	Metadata   Metadata `json:"metadata" validate:"required"`
+	Spec       Spec     `json:"spec" validate:"required"`
+}
+
+// Metadata carries identifying information for the overall spec.
+type Metadata struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// Spec is the body of an OmServiceSpec: the services it deploys, the
+// policies those services run under, and any webhooks notified on
+// lifecycle events.
+type Spec struct {
+	Services []Service `json:"services" validate:"required,min=1,dive"`
+	Policies Policies  `json:"policies"`
+	Webhooks []Webhook `json:"webhooks,omitempty" validate:"dive"`
+}
+
+// Service describes one deployable unit, e.g. the token-budgets API.
+// --services-from merges one Service per manifest file into Spec.Services
+// alongside whatever cmd/handbook builds in code.
+type Service struct {
+	Name          string        `json:"name" yaml:"name" hcl:"name" validate:"required"`
+	Type          string        `json:"type" yaml:"type" hcl:"type" validate:"required,oneof=container process"`
+	Runtime       Runtime       `json:"runtime" yaml:"runtime" hcl:"runtime,block" validate:"required"`
+	Exposure      Exposure      `json:"exposure" yaml:"exposure" hcl:"exposure,block"`
+	Dependencies  []Dependency  `json:"dependencies,omitempty" yaml:"dependencies,omitempty" hcl:"dependency,block" validate:"dive"`
+	Observability Observability `json:"observability" yaml:"observability" hcl:"observability,block"`
+}
+
+// Runtime is how a Service actually runs: its image, command, replica
+// count, resource requests, and the environment/secrets it needs.
+type Runtime struct {
+	Image       string            `json:"image" yaml:"image" hcl:"image" validate:"required"`
+	Command     []string          `json:"command,omitempty" yaml:"command,omitempty" hcl:"command,optional"`
+	Replicas    int               `json:"replicas" yaml:"replicas" hcl:"replicas" validate:"required,min=1"`
+	Resources   map[string]string `json:"resources,omitempty" yaml:"resources,omitempty" hcl:"resources,optional"`
+	Environment []EnvVar          `json:"environment,omitempty" yaml:"environment,omitempty" hcl:"environment,block" validate:"dive"`
+	Secrets     []SecretRef       `json:"secrets,omitempty" yaml:"secrets,omitempty" hcl:"secret,block" validate:"dive"`
+}
+
+// EnvVar is a plaintext environment variable set on the Runtime.
+type EnvVar struct {
+	Name  string `json:"name" yaml:"name" hcl:"name,label" validate:"required"`
+	Value string `json:"value" yaml:"value" hcl:"value"`
+}
+
+// SecretRef points at a secret resolved out-of-band, normally a sops://
+// URI into secrets.env.enc (see internal/secrets).
+type SecretRef struct {
+	Name      string `json:"name" yaml:"name" hcl:"name,label" validate:"required"`
+	SecretRef string `json:"secretRef" yaml:"secretRef" hcl:"secretRef" validate:"required"`
+}
+
+// Exposure is how a Service is reached from outside its own process/pod.
+type Exposure struct {
+	Port    int      `json:"port" yaml:"port" hcl:"port" validate:"required,min=1,max=65535"`
+	Ingress *Ingress `json:"ingress,omitempty" yaml:"ingress,omitempty" hcl:"ingress,block"`
+}
+
+// Ingress requests a routable hostname for a Service's Exposure.
+type Ingress struct {
+	Host string `json:"host" yaml:"host" hcl:"host" validate:"required"`
+}
+
+// Dependency names another service or managed resource this Service
+// requires at runtime, e.g. the Postgres instance behind om-db.
+type Dependency struct {
+	Name    string `json:"name" yaml:"name" hcl:"name,label" validate:"required"`
+	Type    string `json:"type" yaml:"type" hcl:"type" validate:"required"`
+	Service string `json:"service" yaml:"service" hcl:"service" validate:"required"`
+}
+
+// Observability wires a Service's logs and metrics into the rest of the
+// platform's tooling.
+type Observability struct {
+	Logs    *LogSink     `json:"logs,omitempty" yaml:"logs,omitempty" hcl:"logs,block"`
+	Metrics *MetricsSink `json:"metrics,omitempty" yaml:"metrics,omitempty" hcl:"metrics,block"`
+}
+
+// LogSink names the destination a Service's logs are shipped to.
+type LogSink struct {
+	Sink string `json:"sink" yaml:"sink" hcl:"sink" validate:"required"`
+}
+
+// MetricsSink names the dashboard a Service's metrics are visualized on.
+type MetricsSink struct {
+	Dashboard string `json:"dashboard" yaml:"dashboard" hcl:"dashboard" validate:"required"`
+}
+
+// Policies are cross-cutting limits applied across every Service in Spec.
+type Policies struct {
+	TokenBudgets TokenBudgets `json:"tokenBudgets"`
+}
+
+// TokenBudgets mirrors the daily-limit/alert-threshold policy this repo
+// already uses for LLM spend (see internal/room.Budget for the analogous
+// per-conversation version).
+type TokenBudgets struct {
+	DailyLimit     int     `json:"dailyLimit" validate:"min=0"`
+	AlertThreshold float64 `json:"alertThreshold" validate:"min=0,max=1"`
+}
+
+// Webhook notifies an external URL on a named lifecycle event.
+type Webhook struct {
+	Event string `json:"event" yaml:"event" validate:"required"`
+	URL   string `json:"url" yaml:"url" validate:"required,url"`
+}
@@ -0,0 +1,38 @@
+package platformspec
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	validatorOnce sync.Once
+	validatorInst *validator.Validate
+)
+
+func instance() *validator.Validate {
+	validatorOnce.Do(func() { validatorInst = validator.New() })
+	return validatorInst
+}
+
+// Validate checks spec against the `validate` struct tags in types.go,
+// returning one formatted error per failing field so callers (the CLI,
+// cmd/handbook's verify subcommand) can print every problem at once
+// instead of bailing out on the first.
+func Validate(spec OmServiceSpec) []string {
+	err := instance().Struct(spec)
+	if err == nil {
+		return nil
+	}
+	verrs, ok := err.(validator.ValidationErrors)
+	if !ok {
+		return []string{err.Error()}
+	}
+	var messages []string
+	for _, fe := range verrs {
+		messages = append(messages, fmt.Sprintf("%s failed the %q rule (got %v)", fe.Namespace(), fe.Tag(), fe.Value()))
+	}
+	return messages
+}